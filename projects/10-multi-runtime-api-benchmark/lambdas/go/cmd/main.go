@@ -2,23 +2,29 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log"
 	"net/http"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
 	ginadapter "github.com/awslabs/aws-lambda-go-api-proxy/gin"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/vibtellect/benchmark-go-lambda/internal/auth"
 	"github.com/vibtellect/benchmark-go-lambda/internal/models"
+	"github.com/vibtellect/benchmark-go-lambda/internal/transport"
 	"github.com/vibtellect/benchmark-go-lambda/internal/utils"
 )
 
 var (
-	ginLambda      *ginadapter.GinLambda
-	dbClient       *utils.DynamoDBClient
+	ginLambda        *ginadapter.GinLambda
+	dbClient         *utils.DynamoDBClient
 	metricsCollector *utils.MetricsCollector
+	tracerShutdown   func(context.Context) error
 )
 
 func init() {
@@ -27,6 +33,11 @@ func init() {
 
 	// Initialize metrics collector
 	metricsCollector = utils.NewMetricsCollector()
+	utils.Metrics = metricsCollector
+
+	// Wire up OpenTelemetry tracing; a no-op provider when
+	// OTEL_EXPORTER_OTLP_ENDPOINT isn't set.
+	tracerShutdown = utils.InitTracer(context.Background())
 
 	// Set Gin mode
 	ginMode := os.Getenv("GIN_MODE")
@@ -35,8 +46,11 @@ func init() {
 	}
 	gin.SetMode(ginMode)
 
-	// Initialize Gin
-	r := gin.Default()
+	// Initialize Gin. gin.Default()'s stdout access logger is plain text and
+	// not worth keeping alongside tracingMiddleware's structured log line
+	// below, so only gin.Recovery() carries over.
+	r := gin.New()
+	r.Use(gin.Recovery())
 
 	// Configure CORS
 	r.Use(func(c *gin.Context) {
@@ -52,6 +66,10 @@ func init() {
 		c.Next()
 	})
 
+	// Trace and account every request, and tag the DynamoDB calls it
+	// triggers with the same request ID structured logs use.
+	r.Use(tracingMiddleware)
+
 	// Health check endpoints
 	r.GET("/health", healthHandler)
 	r.GET("/go/health", healthHandler)
@@ -59,42 +77,241 @@ func init() {
 	// Metrics endpoints
 	r.GET("/metrics", metricsHandler)
 	r.GET("/go/metrics", metricsHandler)
+	r.GET("/metrics/prom", prometheusMetricsHandler)
+	r.GET("/go/metrics/prom", prometheusMetricsHandler)
+
+	// Auth endpoints are public; they're what mints the Bearer token every
+	// /items* route below requires.
+	r.POST("/auth/login", loginHandler)
+	r.POST("/auth/refresh", refreshHandler)
+	r.POST("/go/auth/login", loginHandler)
+	r.POST("/go/auth/refresh", refreshHandler)
 
 	// Items endpoints
 	items := r.Group("")
+	items.Use(auth.Middleware)
 	{
 		items.POST("/items", createItemHandler)
 		items.GET("/items", listItemsHandler)
 		items.GET("/items/:id", getItemHandler)
 		items.PUT("/items/:id", updateItemHandler)
 		items.DELETE("/items/:id", deleteItemHandler)
+		items.POST("/items/batch", batchCreateItemsHandler)
+		items.POST("/items/batch-get", batchGetItemsHandler)
+		items.POST("/items/transact", transactItemsHandler)
+		items.POST("/items/bulk", bulkCreateItemsHandler)
+		items.PUT("/items/bulk", bulkUpdateItemsHandler)
+		items.DELETE("/items/bulk", bulkDeleteItemsHandler)
 	}
 
 	// Go-prefixed routes
 	goItems := r.Group("/go")
+	goItems.Use(auth.Middleware)
 	{
 		goItems.POST("/items", createItemHandler)
 		goItems.GET("/items", listItemsHandler)
 		goItems.GET("/items/:id", getItemHandler)
 		goItems.PUT("/items/:id", updateItemHandler)
 		goItems.DELETE("/items/:id", deleteItemHandler)
+		goItems.POST("/items/batch", batchCreateItemsHandler)
+		goItems.POST("/items/batch-get", batchGetItemsHandler)
+		goItems.POST("/items/transact", transactItemsHandler)
+		goItems.POST("/items/bulk", bulkCreateItemsHandler)
+		goItems.PUT("/items/bulk", bulkUpdateItemsHandler)
+		goItems.DELETE("/items/bulk", bulkDeleteItemsHandler)
 	}
 
 	ginLambda = ginadapter.New(r)
 }
 
+// tracingMiddleware starts an OTel span per request, stamps a request ID
+// (reusing an inbound X-Request-ID if present), and threads both through
+// the request context so DynamoDBClient's structured logs and spans can be
+// correlated back to the HTTP request that caused them. It also logs the
+// completed request itself, replacing gin.Default()'s plain-text access log
+// with a structured line CloudWatch Insights can query.
+func tracingMiddleware(c *gin.Context) {
+	utils.IncrementRequestCount()
+	start := time.Now()
+
+	requestID := c.GetHeader("X-Request-ID")
+	if requestID == "" {
+		requestID = uuid.New().String()
+	}
+	c.Writer.Header().Set("X-Request-ID", requestID)
+
+	ctx, span := utils.Tracer.Start(c.Request.Context(), c.Request.Method+" "+c.FullPath())
+	defer span.End()
+
+	ctx = utils.WithRequestID(ctx, requestID)
+	c.Request = c.Request.WithContext(ctx)
+	c.Next()
+
+	latency := time.Since(start)
+	utils.RecordHTTPRequest(c.FullPath(), c.Request.Method, c.Writer.Status(), latency)
+	utils.LogHTTPRequest(ctx, c.Request.Method, c.FullPath(), c.Writer.Status(), latency)
+}
+
+// respondValidationError renders a models.Validate failure as a 400 with
+// per-field {field, tag, message} triples when err is a *models.ValidationError,
+// falling back to a plain message for any other error type.
+func respondValidationError(c *gin.Context, err error) {
+	var validationErr *models.ValidationError
+	if errors.As(err, &validationErr) {
+		transport.Respond(c, http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Message: "Validation failed",
+			Error:   err.Error(),
+			Fields:  validationErr.Errors,
+		})
+		return
+	}
+
+	transport.Respond(c, http.StatusBadRequest, models.ErrorResponse{
+		Success: false,
+		Message: "Invalid request data",
+		Error:   err.Error(),
+	})
+}
+
+// expectedVersionFromRequest resolves the caller's expected item version for
+// optimistic concurrency: an If-Match header takes precedence over a
+// "version" field sent in the request (body for updates, query for deletes).
+func expectedVersionFromRequest(c *gin.Context, bodyVersion *int64) (*int64, error) {
+	ifMatch := c.GetHeader("If-Match")
+	if ifMatch == "" {
+		return bodyVersion, nil
+	}
+
+	v, err := strconv.ParseInt(ifMatch, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// loginHandler mints an access/refresh token pair for any non-blank
+// username/password pair - see models.LoginRequest for why there's no real
+// credential check.
+func loginHandler(c *gin.Context) {
+	var req models.LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		transport.Respond(c, http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if err := models.Validate(req); err != nil {
+		respondValidationError(c, err)
+		return
+	}
+
+	userID := auth.DeriveUserID(req.Username)
+
+	accessToken, err := auth.GenerateAccessToken(userID, req.Username)
+	if err != nil {
+		transport.Respond(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success: false,
+			Message: "Error generating access token",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	refreshToken, err := auth.GenerateRefreshToken(userID, req.Username)
+	if err != nil {
+		transport.Respond(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success: false,
+			Message: "Error generating refresh token",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	transport.Respond(c, http.StatusOK, models.TokenResponse{
+		Success:      true,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(auth.AccessTokenTTL.Seconds()),
+		Message:      "Login successful",
+	})
+}
+
+// refreshHandler exchanges a valid refresh token for a new access token,
+// without requiring the caller's credentials again.
+func refreshHandler(c *gin.Context) {
+	var req models.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		transport.Respond(c, http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if err := models.Validate(req); err != nil {
+		respondValidationError(c, err)
+		return
+	}
+
+	claims, err := auth.ParseToken(req.RefreshToken)
+	if err != nil {
+		transport.Respond(c, http.StatusUnauthorized, models.ErrorResponse{
+			Success: false,
+			Message: "Invalid or expired refresh token",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	accessToken, err := auth.GenerateAccessToken(claims.UserID, claims.Username)
+	if err != nil {
+		transport.Respond(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success: false,
+			Message: "Error generating access token",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	transport.Respond(c, http.StatusOK, models.TokenResponse{
+		Success:     true,
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(auth.AccessTokenTTL.Seconds()),
+		Message:     "Token refreshed",
+	})
+}
+
+func prometheusMetricsHandler(c *gin.Context) {
+	c.String(http.StatusOK, utils.PrometheusText())
+}
+
 func healthHandler(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
+	daxCalls, dynamoCalls := utils.BackendCallCounts()
+
+	transport.Respond(c, http.StatusOK, gin.H{
 		"status":    "healthy",
 		"runtime":   "go",
 		"version":   "1.21",
 		"framework": "Gin",
+		"dynamodb": gin.H{
+			"backend":      dbClient.Backend,
+			"dax_calls":    daxCalls,
+			"direct_calls": dynamoCalls,
+		},
 	})
 }
 
 func metricsHandler(c *gin.Context) {
 	metrics := metricsCollector.GetMetrics()
-	c.JSON(http.StatusOK, gin.H{
+	metricsCollector.Emit(c.Request.Context())
+	transport.Respond(c, http.StatusOK, gin.H{
 		"success": true,
 		"data":    metrics,
 	})
@@ -102,8 +319,8 @@ func metricsHandler(c *gin.Context) {
 
 func createItemHandler(c *gin.Context) {
 	var itemData models.ItemCreate
-	if err := c.ShouldBindJSON(&itemData); err != nil {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+	if err := transport.Bind(c, &itemData); err != nil {
+		transport.Respond(c, http.StatusBadRequest, models.ErrorResponse{
 			Success: false,
 			Message: "Invalid request data",
 			Error:   err.Error(),
@@ -111,9 +328,14 @@ func createItemHandler(c *gin.Context) {
 		return
 	}
 
-	item, err := dbClient.CreateItem(itemData)
+	if err := models.Validate(itemData); err != nil {
+		respondValidationError(c, err)
+		return
+	}
+
+	item, err := dbClient.CreateItem(c.Request.Context(), itemData, c.GetString("userID"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		transport.Respond(c, http.StatusInternalServerError, models.ErrorResponse{
 			Success: false,
 			Message: "Error creating item",
 			Error:   err.Error(),
@@ -121,7 +343,7 @@ func createItemHandler(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, models.ItemResponse{
+	transport.Respond(c, http.StatusCreated, models.ItemResponse{
 		Success: true,
 		Data:    item,
 		Message: "Item created successfully",
@@ -131,9 +353,9 @@ func createItemHandler(c *gin.Context) {
 func getItemHandler(c *gin.Context) {
 	itemID := c.Param("id")
 
-	item, err := dbClient.GetItem(itemID)
+	item, err := dbClient.GetItem(c.Request.Context(), itemID, c.GetString("userID"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		transport.Respond(c, http.StatusInternalServerError, models.ErrorResponse{
 			Success: false,
 			Message: "Error getting item",
 			Error:   err.Error(),
@@ -142,14 +364,14 @@ func getItemHandler(c *gin.Context) {
 	}
 
 	if item == nil {
-		c.JSON(http.StatusNotFound, models.ErrorResponse{
+		transport.Respond(c, http.StatusNotFound, models.ErrorResponse{
 			Success: false,
 			Message: "Item not found: " + itemID,
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, models.ItemResponse{
+	transport.Respond(c, http.StatusOK, models.ItemResponse{
 		Success: true,
 		Data:    item,
 		Message: "Item retrieved successfully",
@@ -160,8 +382,8 @@ func updateItemHandler(c *gin.Context) {
 	itemID := c.Param("id")
 
 	var itemData models.ItemUpdate
-	if err := c.ShouldBindJSON(&itemData); err != nil {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+	if err := transport.Bind(c, &itemData); err != nil {
+		transport.Respond(c, http.StatusBadRequest, models.ErrorResponse{
 			Success: false,
 			Message: "Invalid request data",
 			Error:   err.Error(),
@@ -169,9 +391,32 @@ func updateItemHandler(c *gin.Context) {
 		return
 	}
 
-	item, err := dbClient.UpdateItem(itemID, itemData)
+	if err := models.Validate(itemData); err != nil {
+		respondValidationError(c, err)
+		return
+	}
+
+	expectedVersion, err := expectedVersionFromRequest(c, itemData.Version)
+	if err != nil {
+		transport.Respond(c, http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Message: "Invalid If-Match header",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	item, err := dbClient.UpdateItem(c.Request.Context(), itemID, itemData, expectedVersion, c.GetString("userID"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		if errors.Is(err, utils.ErrVersionMismatch) {
+			transport.Respond(c, http.StatusPreconditionFailed, models.ErrorResponse{
+				Success: false,
+				Message: "Item was modified by another request",
+				Error:   err.Error(),
+			})
+			return
+		}
+		transport.Respond(c, http.StatusInternalServerError, models.ErrorResponse{
 			Success: false,
 			Message: "Error updating item",
 			Error:   err.Error(),
@@ -180,14 +425,14 @@ func updateItemHandler(c *gin.Context) {
 	}
 
 	if item == nil {
-		c.JSON(http.StatusNotFound, models.ErrorResponse{
+		transport.Respond(c, http.StatusNotFound, models.ErrorResponse{
 			Success: false,
 			Message: "Item not found: " + itemID,
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, models.ItemResponse{
+	transport.Respond(c, http.StatusOK, models.ItemResponse{
 		Success: true,
 		Data:    item,
 		Message: "Item updated successfully",
@@ -197,9 +442,32 @@ func updateItemHandler(c *gin.Context) {
 func deleteItemHandler(c *gin.Context) {
 	itemID := c.Param("id")
 
-	deleted, err := dbClient.DeleteItem(itemID)
+	var bodyVersion *int64
+	if v, err := strconv.ParseInt(c.Query("version"), 10, 64); err == nil {
+		bodyVersion = &v
+	}
+
+	expectedVersion, err := expectedVersionFromRequest(c, bodyVersion)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		transport.Respond(c, http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Message: "Invalid If-Match header",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	deleted, err := dbClient.DeleteItem(c.Request.Context(), itemID, expectedVersion, c.GetString("userID"))
+	if err != nil {
+		if errors.Is(err, utils.ErrVersionMismatch) {
+			transport.Respond(c, http.StatusPreconditionFailed, models.ErrorResponse{
+				Success: false,
+				Message: "Item was modified by another request",
+				Error:   err.Error(),
+			})
+			return
+		}
+		transport.Respond(c, http.StatusInternalServerError, models.ErrorResponse{
 			Success: false,
 			Message: "Error deleting item",
 			Error:   err.Error(),
@@ -208,29 +476,93 @@ func deleteItemHandler(c *gin.Context) {
 	}
 
 	if !deleted {
-		c.JSON(http.StatusNotFound, models.ErrorResponse{
+		transport.Respond(c, http.StatusNotFound, models.ErrorResponse{
 			Success: false,
 			Message: "Item not found: " + itemID,
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	transport.Respond(c, http.StatusOK, gin.H{
 		"success": true,
 		"message": "Item " + itemID + " deleted successfully",
 	})
 }
 
+// listItemsHandler is modeled on Homebox's HandleItemsGetAll: page/pageSize
+// are echoed back for display, but actual pagination is cursor-based (see
+// cursor/next_cursor) since DynamoDB has no cheap offset seek. q, labels,
+// categories, and include_archived build a FilterExpression layered on top
+// of whichever GSI index/name_prefix/min_price/max_price already selects,
+// and sort_column/sort_order re-order the returned page in memory.
 func listItemsHandler(c *gin.Context) {
-	limitStr := c.DefaultQuery("limit", "100")
+	limitStr := c.DefaultQuery("pageSize", c.DefaultQuery("limit", "100"))
 	limit, err := strconv.ParseInt(limitStr, 10, 64)
 	if err != nil {
 		limit = 100
 	}
 
-	items, err := dbClient.ListItems(limit)
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	startKey, err := utils.DecodeCursor(c.Query("cursor"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		transport.Respond(c, http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Message: "Invalid cursor",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	includeArchived, _ := strconv.ParseBool(c.Query("include_archived"))
+
+	listInput := utils.ListItemsInput{
+		Limit:             limit,
+		ExclusiveStartKey: startKey,
+		IndexName:         c.Query("index"),
+		NamePrefix:        c.Query("name_prefix"),
+		Search:            c.Query("q"),
+		Categories:        c.QueryArray("categories"),
+		Labels:            c.QueryArray("labels"),
+		IncludeArchived:   includeArchived,
+		SortColumn:        c.Query("sort_column"),
+		SortOrder:         c.Query("sort_order"),
+		IncludeTotal:      true,
+		OwnerID:           c.GetString("userID"),
+	}
+
+	if minPrice := c.Query("min_price"); minPrice != "" {
+		v, err := strconv.ParseFloat(minPrice, 64)
+		if err != nil {
+			transport.Respond(c, http.StatusBadRequest, models.ErrorResponse{
+				Success: false,
+				Message: "Invalid min_price",
+				Error:   err.Error(),
+			})
+			return
+		}
+		listInput.MinPrice = &v
+	}
+
+	if maxPrice := c.Query("max_price"); maxPrice != "" {
+		v, err := strconv.ParseFloat(maxPrice, 64)
+		if err != nil {
+			transport.Respond(c, http.StatusBadRequest, models.ErrorResponse{
+				Success: false,
+				Message: "Invalid max_price",
+				Error:   err.Error(),
+			})
+			return
+		}
+		listInput.MaxPrice = &v
+	}
+
+	items, lastKey, total, err := dbClient.ListItems(c.Request.Context(), listInput)
+	if err != nil {
+		transport.Respond(c, http.StatusInternalServerError, models.ErrorResponse{
 			Success: false,
 			Message: "Error listing items",
 			Error:   err.Error(),
@@ -238,14 +570,181 @@ func listItemsHandler(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, models.ItemListResponse{
+	nextCursor, err := utils.EncodeCursor(lastKey)
+	if err != nil {
+		transport.Respond(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success: false,
+			Message: "Error encoding pagination cursor",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	transport.Respond(c, http.StatusOK, models.ItemListResponse{
+		Success:    true,
+		Data:       items,
+		Count:      len(items),
+		Total:      total,
+		Page:       page,
+		PageSize:   int(limit),
+		NextCursor: nextCursor,
+		Message:    "Items retrieved successfully",
+	})
+}
+
+func batchCreateItemsHandler(c *gin.Context) {
+	var req models.BatchWriteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		transport.Respond(c, http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	created, err := dbClient.BatchCreateItems(c.Request.Context(), req.Items, c.GetString("userID"))
+	if err != nil {
+		transport.Respond(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success: false,
+			Message: "Error batch creating items",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	transport.Respond(c, http.StatusCreated, models.BatchWriteResponse{
+		Success: true,
+		Created: created,
+		Failed:  len(req.Items) - len(created),
+		Message: "Batch create completed",
+	})
+}
+
+func batchGetItemsHandler(c *gin.Context) {
+	var req models.BatchGetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		transport.Respond(c, http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	items, missing, err := dbClient.BatchGetItems(c.Request.Context(), req.IDs, c.GetString("userID"))
+	if err != nil {
+		transport.Respond(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success: false,
+			Message: "Error batch getting items",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	transport.Respond(c, http.StatusOK, models.BatchGetResponse{
 		Success: true,
 		Data:    items,
-		Count:   len(items),
-		Message: "Items retrieved successfully",
+		Missing: missing,
+		Message: "Batch get completed",
+	})
+}
+
+func transactItemsHandler(c *gin.Context) {
+	var req models.TransactRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		transport.Respond(c, http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if err := dbClient.TransactItems(c.Request.Context(), req.Operations, c.GetString("userID")); err != nil {
+		transport.Respond(c, http.StatusInternalServerError, models.ErrorResponse{
+			Success: false,
+			Message: "Error executing transaction",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	transport.Respond(c, http.StatusOK, models.TransactResponse{
+		Success: true,
+		Message: "Transaction committed",
 	})
 }
 
+// bulkAtomicParam reads the ?atomic=true query param shared by the three
+// bulk handlers below. An invalid or absent value means non-atomic.
+func bulkAtomicParam(c *gin.Context) bool {
+	atomic, _ := strconv.ParseBool(c.Query("atomic"))
+	return atomic
+}
+
+// bulkResponse summarizes per-item results into a models.BulkResponse.
+func bulkResponse(results []models.BulkResult) models.BulkResponse {
+	succeeded := 0
+	for _, r := range results {
+		if r.Success {
+			succeeded++
+		}
+	}
+	return models.BulkResponse{
+		Success:   succeeded > 0,
+		Results:   results,
+		Succeeded: succeeded,
+		Failed:    len(results) - succeeded,
+		Message:   "Bulk operation completed",
+	}
+}
+
+func bulkCreateItemsHandler(c *gin.Context) {
+	var req models.BulkCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		transport.Respond(c, http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	results := dbClient.BulkCreateItems(c.Request.Context(), req.Items, c.GetString("userID"), bulkAtomicParam(c))
+	transport.Respond(c, http.StatusCreated, bulkResponse(results))
+}
+
+func bulkUpdateItemsHandler(c *gin.Context) {
+	var req models.BulkUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		transport.Respond(c, http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	results := dbClient.BulkUpdateItems(c.Request.Context(), req.Items, c.GetString("userID"), bulkAtomicParam(c))
+	transport.Respond(c, http.StatusOK, bulkResponse(results))
+}
+
+func bulkDeleteItemsHandler(c *gin.Context) {
+	var req models.BulkDeleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		transport.Respond(c, http.StatusBadRequest, models.ErrorResponse{
+			Success: false,
+			Message: "Invalid request data",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	results := dbClient.BulkDeleteItems(c.Request.Context(), req.Items, c.GetString("userID"), bulkAtomicParam(c))
+	transport.Respond(c, http.StatusOK, bulkResponse(results))
+}
+
 // Handler is the Lambda function handler
 func Handler(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	return ginLambda.ProxyWithContext(ctx, req)
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -231,6 +232,29 @@ func TestUpdateItemHandlerInvalidValues(t *testing.T) {
 	}
 }
 
+func TestUpdateItemHandlerInvalidIfMatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	c.Params = []gin.Param{
+		{Key: "id", Value: "test-id"},
+	}
+
+	body := bytes.NewBufferString(`{"name":"Renamed"}`)
+	req, _ := http.NewRequest("PUT", "/items/test-id", body)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", "not-a-number")
+	c.Request = req
+
+	updateItemHandler(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for non-numeric If-Match, got %d", w.Code)
+	}
+}
+
 func TestGetItemHandlerMissingID(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -428,6 +452,213 @@ func TestItemListResponse(t *testing.T) {
 	}
 }
 
+func TestPrometheusMetricsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	req, _ := http.NewRequest("GET", "/metrics/prom", nil)
+	c.Request = req
+
+	prometheusMetricsHandler(c)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", w.Code)
+	}
+
+	if !strings.Contains(w.Body.String(), "go_lambda_requests_total") {
+		t.Errorf("Expected Prometheus body to contain request counter, got: %s", w.Body.String())
+	}
+}
+
+func TestBatchCreateItemsHandlerInvalidJSON(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	body := bytes.NewBufferString(`{"items":}`)
+	req, _ := http.NewRequest("POST", "/items/batch", body)
+	req.Header.Set("Content-Type", "application/json")
+	c.Request = req
+
+	batchCreateItemsHandler(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+}
+
+func TestBatchCreateItemsHandlerTooManyItems(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	items := make([]string, 26)
+	for i := range items {
+		items[i] = `{"name":"Item","price":9.99}`
+	}
+	body := bytes.NewBufferString(`{"items":[` + strings.Join(items, ",") + `]}`)
+	req, _ := http.NewRequest("POST", "/items/batch", body)
+	req.Header.Set("Content-Type", "application/json")
+	c.Request = req
+
+	batchCreateItemsHandler(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for more than 25 items, got %d", w.Code)
+	}
+}
+
+func TestBatchGetItemsHandlerInvalidJSON(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	body := bytes.NewBufferString(`{"ids":}`)
+	req, _ := http.NewRequest("POST", "/items/batch-get", body)
+	req.Header.Set("Content-Type", "application/json")
+	c.Request = req
+
+	batchGetItemsHandler(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+}
+
+func TestTransactItemsHandlerInvalidOp(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	body := bytes.NewBufferString(`{"operations":[{"op":"explode","id":"1"}]}`)
+	req, _ := http.NewRequest("POST", "/items/transact", body)
+	req.Header.Set("Content-Type", "application/json")
+	c.Request = req
+
+	transactItemsHandler(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for unknown op, got %d", w.Code)
+	}
+}
+
+func TestBulkCreateItemsHandlerInvalidJSON(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	body := bytes.NewBufferString(`{"items":}`)
+	req, _ := http.NewRequest("POST", "/items/bulk", body)
+	req.Header.Set("Content-Type", "application/json")
+	c.Request = req
+
+	bulkCreateItemsHandler(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+}
+
+func TestBulkCreateItemsHandlerMissingClientRef(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	body := bytes.NewBufferString(`{"items":[{"name":"Item","price":9.99}]}`)
+	req, _ := http.NewRequest("POST", "/items/bulk", body)
+	req.Header.Set("Content-Type", "application/json")
+	c.Request = req
+
+	bulkCreateItemsHandler(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for missing client_ref, got %d", w.Code)
+	}
+}
+
+func TestBulkCreateItemsHandlerTooManyItems(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	items := make([]string, 101)
+	for i := range items {
+		items[i] = `{"client_ref":"ref","name":"Item","price":9.99}`
+	}
+	body := bytes.NewBufferString(`{"items":[` + strings.Join(items, ",") + `]}`)
+	req, _ := http.NewRequest("POST", "/items/bulk", body)
+	req.Header.Set("Content-Type", "application/json")
+	c.Request = req
+
+	bulkCreateItemsHandler(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for more than 100 items, got %d", w.Code)
+	}
+}
+
+func TestBulkUpdateItemsHandlerInvalidJSON(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	body := bytes.NewBufferString(`{"items":}`)
+	req, _ := http.NewRequest("PUT", "/items/bulk", body)
+	req.Header.Set("Content-Type", "application/json")
+	c.Request = req
+
+	bulkUpdateItemsHandler(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+}
+
+func TestBulkDeleteItemsHandlerInvalidJSON(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	body := bytes.NewBufferString(`{"items":}`)
+	req, _ := http.NewRequest("DELETE", "/items/bulk", body)
+	req.Header.Set("Content-Type", "application/json")
+	c.Request = req
+
+	bulkDeleteItemsHandler(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+}
+
+func TestBulkAtomicParam(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("POST", "/items/bulk?atomic=true", nil)
+
+	if !bulkAtomicParam(c) {
+		t.Error("Expected bulkAtomicParam to be true for ?atomic=true")
+	}
+
+	c.Request, _ = http.NewRequest("POST", "/items/bulk", nil)
+	if bulkAtomicParam(c) {
+		t.Error("Expected bulkAtomicParam to be false when atomic isn't set")
+	}
+}
+
 func TestCORSHeaders(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
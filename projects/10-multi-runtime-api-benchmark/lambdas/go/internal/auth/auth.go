@@ -0,0 +1,140 @@
+// Package auth implements Bearer JWT authentication for the benchmark API:
+// a valid token carries the caller's identity (sub/username) into the Gin
+// context, which DynamoDBClient uses to scope items to their owner.
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// AccessTokenTTL and RefreshTokenTTL are how long tokens minted by
+// GenerateAccessToken/GenerateRefreshToken remain valid.
+const (
+	AccessTokenTTL  = 15 * time.Minute
+	RefreshTokenTTL = 7 * 24 * time.Hour
+)
+
+// ErrMissingToken and ErrInvalidToken are the reasons Middleware rejects a
+// request; ErrInvalidToken is also what ParseToken returns for any token it
+// rejects.
+var (
+	ErrMissingToken = errors.New("missing bearer token")
+	ErrInvalidToken = errors.New("invalid or expired token")
+)
+
+// Claims is the JWT payload minted by GenerateAccessToken/GenerateRefreshToken
+// and expected from every Bearer token. UserID is what DynamoDBClient's
+// ownership checks compare against.
+type Claims struct {
+	UserID   string `json:"sub"`
+	Username string `json:"username"`
+	jwt.RegisteredClaims
+}
+
+// jwtSecret returns the HS256 signing secret from JWT_SECRET. It falls back
+// to a fixed development secret when unset so local/non-Lambda runs need no
+// extra setup; a real deployment must set JWT_SECRET.
+func jwtSecret() []byte {
+	if secret := os.Getenv("JWT_SECRET"); secret != "" {
+		return []byte(secret)
+	}
+	return []byte("dev-only-insecure-secret")
+}
+
+// DeriveUserID maps a username to a deterministic owner ID. This benchmark
+// API has no real user store, so login accepts any non-blank credentials;
+// hashing the username means the same login always owns the same items.
+func DeriveUserID(username string) string {
+	sum := sha256.Sum256([]byte(username))
+	return "user-" + hex.EncodeToString(sum[:])[:16]
+}
+
+// GenerateAccessToken mints a short-lived HS256 token carrying userID/username.
+func GenerateAccessToken(userID, username string) (string, error) {
+	return signToken(userID, username, AccessTokenTTL)
+}
+
+// GenerateRefreshToken mints a longer-lived HS256 token for POST
+// /auth/refresh. It carries the same claims as an access token, so
+// ParseToken and Middleware do not tell the two kinds apart: a refresh
+// token is also accepted as a Bearer token on guarded routes.
+func GenerateRefreshToken(userID, username string) (string, error) {
+	return signToken(userID, username, RefreshTokenTTL)
+}
+
+func signToken(userID, username string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims := Claims{
+		UserID:   userID,
+		Username: username,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(jwtSecret())
+}
+
+// ParseToken validates an HS256 token minted by this package, or - when
+// COGNITO_JWKS_URL is set - an RS256 token issued by a Cognito user pool,
+// matched against the pool's JWKS by its "kid" header.
+func ParseToken(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, keyFunc)
+	if err != nil || !token.Valid {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
+
+func keyFunc(token *jwt.Token) (interface{}, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		return jwtSecret(), nil
+	case *jwt.SigningMethodRSA:
+		kid, _ := token.Header["kid"].(string)
+		return cognitoJWKS.lookup(kid)
+	default:
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+}
+
+// Middleware validates the Authorization: Bearer <token> header on every
+// request it guards and stamps "userID"/"username" into the Gin context for
+// handlers and DynamoDBClient ownership checks to read.
+func Middleware(c *gin.Context) {
+	header := c.GetHeader("Authorization")
+	const prefix = "Bearer "
+	if !strings.HasPrefix(header, prefix) {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"success": false,
+			"message": ErrMissingToken.Error(),
+		})
+		return
+	}
+
+	claims, err := ParseToken(strings.TrimPrefix(header, prefix))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"success": false,
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.Set("userID", claims.UserID)
+	c.Set("username", claims.Username)
+	c.Next()
+}
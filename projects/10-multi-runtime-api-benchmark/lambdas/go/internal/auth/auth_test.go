@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestGenerateAndParseAccessToken(t *testing.T) {
+	token, err := GenerateAccessToken("user-123", "alice")
+	if err != nil {
+		t.Fatalf("GenerateAccessToken returned error: %v", err)
+	}
+
+	claims, err := ParseToken(token)
+	if err != nil {
+		t.Fatalf("ParseToken returned error: %v", err)
+	}
+	if claims.UserID != "user-123" {
+		t.Errorf("Expected UserID 'user-123', got %q", claims.UserID)
+	}
+	if claims.Username != "alice" {
+		t.Errorf("Expected Username 'alice', got %q", claims.Username)
+	}
+}
+
+func TestParseTokenRejectsGarbage(t *testing.T) {
+	if _, err := ParseToken("not-a-jwt"); err != ErrInvalidToken {
+		t.Errorf("Expected ErrInvalidToken, got %v", err)
+	}
+}
+
+func TestDeriveUserIDIsStableAndUnique(t *testing.T) {
+	if DeriveUserID("alice") != DeriveUserID("alice") {
+		t.Error("Expected DeriveUserID to be deterministic for the same username")
+	}
+	if DeriveUserID("alice") == DeriveUserID("bob") {
+		t.Error("Expected different usernames to derive different user IDs")
+	}
+}
+
+func TestMiddlewareRejectsMissingToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/items", nil)
+
+	Middleware(c)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status 401, got %d", w.Code)
+	}
+}
+
+func TestMiddlewareAcceptsValidToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	token, err := GenerateAccessToken("user-123", "alice")
+	if err != nil {
+		t.Fatalf("GenerateAccessToken returned error: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/items", nil)
+	c.Request.Header.Set("Authorization", "Bearer "+token)
+
+	Middleware(c)
+
+	if c.IsAborted() {
+		t.Errorf("Expected Middleware to let the request through, got status %d", w.Code)
+	}
+	if userID, _ := c.Get("userID"); userID != "user-123" {
+		t.Errorf("Expected userID 'user-123' in context, got %v", userID)
+	}
+}
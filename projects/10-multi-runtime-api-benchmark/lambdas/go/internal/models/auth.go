@@ -0,0 +1,27 @@
+package models
+
+// LoginRequest represents a login attempt. This benchmark API has no real
+// user store: any non-blank username/password pair is accepted, and the
+// same username always maps to the same deterministic owner (see
+// auth.DeriveUserID) so a caller's items stay visible across logins.
+type LoginRequest struct {
+	Username string `json:"username" binding:"required" validate:"required,notblank"`
+	Password string `json:"password" binding:"required" validate:"required,notblank"`
+}
+
+// RefreshRequest represents a request to exchange a refresh token for a new
+// access token.
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required" validate:"required,notblank"`
+}
+
+// TokenResponse represents a minted access/refresh token pair returned by
+// POST /auth/login and POST /auth/refresh.
+type TokenResponse struct {
+	Success      bool   `json:"success"`
+	AccessToken  string `json:"access_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	TokenType    string `json:"token_type,omitempty"`
+	ExpiresIn    int    `json:"expires_in,omitempty"`
+	Message      string `json:"message,omitempty"`
+}
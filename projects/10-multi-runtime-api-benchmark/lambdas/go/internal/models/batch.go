@@ -0,0 +1,107 @@
+package models
+
+// BatchWriteRequest represents a request to create up to 25 items at once.
+type BatchWriteRequest struct {
+	Items []ItemCreate `json:"items" binding:"required,min=1,max=25,dive"`
+}
+
+// BatchWriteResponse represents the outcome of a batch create.
+type BatchWriteResponse struct {
+	Success bool   `json:"success"`
+	Created []Item `json:"created"`
+	Failed  int    `json:"failed"`
+	Message string `json:"message,omitempty"`
+}
+
+// BatchGetRequest represents a request to fetch up to 100 items by ID.
+type BatchGetRequest struct {
+	IDs []string `json:"ids" binding:"required,min=1,max=100"`
+}
+
+// BatchGetResponse represents the outcome of a batch get. Missing lists IDs
+// that had no matching item.
+type BatchGetResponse struct {
+	Success bool     `json:"success"`
+	Data    []Item   `json:"data"`
+	Missing []string `json:"missing,omitempty"`
+	Message string   `json:"message,omitempty"`
+}
+
+// TransactOp is one heterogeneous operation within a TransactRequest.
+type TransactOp struct {
+	Op     string      `json:"op" binding:"required,oneof=put update delete condition-check"`
+	ID     string      `json:"id,omitempty" binding:"required_unless=Op put"`
+	Item   *ItemCreate `json:"item,omitempty"`
+	Update *ItemUpdate `json:"update,omitempty"`
+}
+
+// TransactRequest represents a heterogeneous list of operations to apply
+// atomically via DynamoDB TransactWriteItems.
+type TransactRequest struct {
+	Operations []TransactOp `json:"operations" binding:"required,min=1,max=25,dive"`
+}
+
+// TransactResponse represents the outcome of a transactional write.
+type TransactResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+}
+
+// BulkCreateItem is one item within a POST /items/bulk request. ClientRef is
+// an opaque caller-supplied token echoed back on the matching BulkResult, so
+// callers can correlate results without depending on response ordering or a
+// server-assigned ID a failed create never gets.
+type BulkCreateItem struct {
+	ClientRef string `json:"client_ref" binding:"required"`
+	ItemCreate
+}
+
+// BulkUpdateItem is one item within a PUT /items/bulk request.
+type BulkUpdateItem struct {
+	ClientRef string `json:"client_ref" binding:"required"`
+	ID        string `json:"id" binding:"required"`
+	ItemUpdate
+}
+
+// BulkDeleteItem is one item within a DELETE /items/bulk request.
+type BulkDeleteItem struct {
+	ClientRef string `json:"client_ref" binding:"required"`
+	ID        string `json:"id" binding:"required"`
+}
+
+// BulkCreateRequest is the body of POST /items/bulk.
+type BulkCreateRequest struct {
+	Items []BulkCreateItem `json:"items" binding:"required,min=1,max=100,dive"`
+}
+
+// BulkUpdateRequest is the body of PUT /items/bulk.
+type BulkUpdateRequest struct {
+	Items []BulkUpdateItem `json:"items" binding:"required,min=1,max=100,dive"`
+}
+
+// BulkDeleteRequest is the body of DELETE /items/bulk.
+type BulkDeleteRequest struct {
+	Items []BulkDeleteItem `json:"items" binding:"required,min=1,max=100,dive"`
+}
+
+// BulkResult reports one item's outcome within a bulk create/update/delete,
+// keyed by the caller's client_ref.
+type BulkResult struct {
+	ClientRef string `json:"client_ref"`
+	Success   bool   `json:"success"`
+	Item      *Item  `json:"item,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// BulkResponse represents the outcome of a bulk operation: every item's
+// individual result, plus aggregate counts. Success is false only when every
+// item failed (an all-or-nothing ?atomic=true transaction) or the request
+// couldn't be processed at all; a partial, non-atomic failure still reports
+// Success true with the per-item results carrying the real outcome.
+type BulkResponse struct {
+	Success   bool         `json:"success"`
+	Results   []BulkResult `json:"results"`
+	Succeeded int          `json:"succeeded"`
+	Failed    int          `json:"failed"`
+	Message   string       `json:"message,omitempty"`
+}
@@ -6,26 +6,58 @@ import (
 
 // Item represents a complete item with all metadata
 type Item struct {
-	ID          string  `json:"id" dynamodbav:"id"`
-	Name        string  `json:"name" dynamodbav:"name"`
-	Description string  `json:"description,omitempty" dynamodbav:"description,omitempty"`
-	Price       float64 `json:"price" dynamodbav:"price"`
-	CreatedAt   int64   `json:"created_at" dynamodbav:"created_at"`
-	UpdatedAt   int64   `json:"updated_at" dynamodbav:"updated_at"`
+	ID          string  `json:"id" attributevalue:"id"`
+	Name        string  `json:"name" attributevalue:"name"`
+	Description string  `json:"description,omitempty" attributevalue:"description,omitempty"`
+	Price       float64 `json:"price" attributevalue:"price"`
+	// Category is a single freeform classification, queryable via
+	// category-index. Labels are many-valued, so listing filters them with a
+	// FilterExpression (contains) rather than a GSI - see
+	// utils.DynamoDBClient.ListItems.
+	Category  string   `json:"category,omitempty" attributevalue:"category,omitempty"`
+	Labels    []string `json:"labels,omitempty" attributevalue:"labels,omitempty"`
+	Archived  bool     `json:"archived,omitempty" attributevalue:"archived,omitempty"`
+	// OwnerID is the sub of the authenticated caller that created the item.
+	// GetItem (and therefore UpdateItem/DeleteItem, which call it to check
+	// existence) treats an item owned by someone else as not found, so
+	// ownership never leaks through a 403 distinguishable from a 404.
+	OwnerID   string `json:"owner_id,omitempty" attributevalue:"owner_id,omitempty"`
+	CreatedAt int64  `json:"created_at" attributevalue:"created_at"`
+	UpdatedAt int64  `json:"updated_at" attributevalue:"updated_at"`
+	// Version is bumped on every successful update and used as an optimistic
+	// concurrency token: callers that supply an expected version get a
+	// ConditionExpression check on UpdateItem/DeleteItem.
+	Version int64 `json:"version,omitempty" attributevalue:"version,omitempty"`
+	// ItemType is the constant partition key every item is written under on
+	// the name/price/category GSIs (utils.itemTypePartition), so those
+	// indexes can be queried by sort key instead of scanned. It's an
+	// internal indexing detail, not part of the item's data, so it's
+	// omitted from the JSON the API returns.
+	ItemType string `json:"-" attributevalue:"item_type"`
 }
 
 // ItemCreate represents data for creating a new item
 type ItemCreate struct {
-	Name        string  `json:"name" binding:"required,min=1,max=100"`
-	Description string  `json:"description,omitempty" binding:"max=500"`
-	Price       float64 `json:"price" binding:"required,gt=0"`
+	Name        string   `json:"name" binding:"required,min=1,max=200" validate:"required,notblank,min=1,max=200"`
+	Description string   `json:"description,omitempty" binding:"max=2000" validate:"max=2000"`
+	Price       float64  `json:"price" binding:"required,gt=0" validate:"gt=0,lt=1000000"`
+	Category    string   `json:"category,omitempty" binding:"omitempty,max=200" validate:"omitempty,max=200"`
+	Labels      []string `json:"labels,omitempty" binding:"omitempty,max=20,dive,max=200" validate:"omitempty,max=20,dive,max=200"`
+	Archived    bool     `json:"archived,omitempty"`
 }
 
 // ItemUpdate represents data for updating an existing item
 type ItemUpdate struct {
-	Name        *string  `json:"name,omitempty" binding:"omitempty,min=1,max=100"`
-	Description *string  `json:"description,omitempty" binding:"omitempty,max=500"`
-	Price       *float64 `json:"price,omitempty" binding:"omitempty,gt=0"`
+	Name        *string  `json:"name,omitempty" binding:"omitempty,min=1,max=200" validate:"omitempty,notblank,min=1,max=200"`
+	Description *string  `json:"description,omitempty" binding:"omitempty,max=2000" validate:"omitempty,max=2000"`
+	Price       *float64 `json:"price,omitempty" binding:"omitempty,gt=0" validate:"omitempty,gt=0,lt=1000000"`
+	Category    *string  `json:"category,omitempty" binding:"omitempty,max=200" validate:"omitempty,max=200"`
+	Labels      []string `json:"labels,omitempty" binding:"omitempty,max=20,dive,max=200" validate:"omitempty,max=20,dive,max=200"`
+	Archived    *bool    `json:"archived,omitempty"`
+	// Version, when set, is the version the caller last saw; DynamoDBClient
+	// rejects the update with ErrVersionMismatch if it no longer matches.
+	// An If-Match header takes precedence over this field when both are sent.
+	Version *int64 `json:"version,omitempty" binding:"omitempty,gt=0"`
 }
 
 // ItemResponse represents a standard API response for item operations
@@ -35,12 +67,25 @@ type ItemResponse struct {
 	Message string `json:"message,omitempty"`
 }
 
-// ItemListResponse represents a response for listing items
+// ItemListResponse represents a response for listing items. It doubles as
+// Homebox's PaginationResult: Total, Page, and PageSize are populated by the
+// query/search path (listItemsHandler), the only handler that returns this
+// type.
 type ItemListResponse struct {
 	Success bool   `json:"success"`
 	Data    []Item `json:"data"`
 	Count   int    `json:"count"`
-	Message string `json:"message,omitempty"`
+	// Total is the number of items matching the query across all pages. It
+	// costs a second DynamoDB read pass (Select: COUNT) over the same
+	// Query/Scan, since DynamoDB has no cheaper way to count matching items.
+	Total int `json:"total,omitempty"`
+	// Page is echoed back from the request's page param; DynamoDB pagination
+	// is cursor-based (see NextCursor), so Page is informational only and
+	// not used to compute an offset.
+	Page       int    `json:"page,omitempty"`
+	PageSize   int    `json:"page_size,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	Message    string `json:"message,omitempty"`
 }
 
 // ErrorResponse represents an error response
@@ -48,6 +93,9 @@ type ErrorResponse struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	// Fields carries per-field validation failures when Error was produced
+	// by a *ValidationError, so clients can highlight the offending inputs.
+	Fields []FieldError `json:"fields,omitempty"`
 }
 
 // CurrentTimestamp returns the current Unix timestamp in milliseconds
@@ -206,23 +206,46 @@ func TestItemCreateValidation(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			isValid := tt.input.Name != "" && len(tt.input.Name) > 0
-			// Check for non-whitespace content
-			trimmed := ""
-			for _, c := range tt.input.Name {
-				if c != ' ' && c != '\t' && c != '\n' {
-					trimmed += string(c)
-				}
-			}
-			isValid = isValid && len(trimmed) > 0 && tt.input.Price > 0
+			err := Validate(tt.input)
+			isValid := err == nil
 
 			if isValid != tt.isValid {
-				t.Errorf("Expected validation result %v, got %v", tt.isValid, isValid)
+				t.Errorf("Expected validation result %v, got %v (err: %v)", tt.isValid, isValid, err)
 			}
 		})
 	}
 }
 
+func TestValidateReturnsFieldErrors(t *testing.T) {
+	err := Validate(ItemCreate{Name: "", Price: -5.0})
+	if err == nil {
+		t.Fatal("Expected a validation error")
+	}
+
+	validationErr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("Expected *ValidationError, got %T", err)
+	}
+
+	fieldsSeen := map[string]string{}
+	for _, fe := range validationErr.Errors {
+		fieldsSeen[fe.Field] = fe.Tag
+	}
+
+	if tag, ok := fieldsSeen["Name"]; !ok || tag != "required" {
+		t.Errorf("Expected Name to fail 'required', got %q", tag)
+	}
+	if tag, ok := fieldsSeen["Price"]; !ok || tag != "gt" {
+		t.Errorf("Expected Price to fail 'gt', got %q", tag)
+	}
+}
+
+func TestValidateValidItemCreatePasses(t *testing.T) {
+	if err := Validate(ItemCreate{Name: "Widget", Price: 9.99}); err != nil {
+		t.Errorf("Expected valid ItemCreate to pass, got %v", err)
+	}
+}
+
 func TestItemUpdateJSONMarshaling(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+func init() {
+	// required/min only check the zero value and length, so a name of pure
+	// whitespace otherwise passes; notblank closes that gap.
+	validate.RegisterValidation("notblank", func(fl validator.FieldLevel) bool {
+		return strings.TrimSpace(fl.Field().String()) != ""
+	})
+}
+
+// FieldError is one struct-tag validation failure, rendered in ErrorResponse
+// as a {field, tag, message} triple so clients can highlight the input.
+type FieldError struct {
+	Field   string `json:"field"`
+	Tag     string `json:"tag"`
+	Message string `json:"message"`
+}
+
+// ValidationError carries every FieldError produced by a failed Validate
+// call, so handlers can report all of them in a single 400 response.
+type ValidationError struct {
+	Errors []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		msgs[i] = fe.Message
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Validate runs struct-tag validation (via go-playground/validator) on v
+// and returns a *ValidationError with one FieldError per failing field (the
+// first of that field's tags to fail), or nil if v satisfies its `validate`
+// tags.
+func Validate(v interface{}) error {
+	err := validate.Struct(v)
+	if err == nil {
+		return nil
+	}
+
+	validationErrs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return err
+	}
+
+	fieldErrs := make([]FieldError, len(validationErrs))
+	for i, fe := range validationErrs {
+		fieldErrs[i] = FieldError{
+			Field:   fe.Field(),
+			Tag:     fe.Tag(),
+			Message: fieldMessage(fe),
+		}
+	}
+	return &ValidationError{Errors: fieldErrs}
+}
+
+func fieldMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return fmt.Sprintf("%s is required", fe.Field())
+	case "min":
+		return fmt.Sprintf("%s must be at least %s characters", fe.Field(), fe.Param())
+	case "max":
+		return fmt.Sprintf("%s must be at most %s characters", fe.Field(), fe.Param())
+	case "gt":
+		return fmt.Sprintf("%s must be greater than %s", fe.Field(), fe.Param())
+	case "lt":
+		return fmt.Sprintf("%s must be less than %s", fe.Field(), fe.Param())
+	default:
+		return fmt.Sprintf("%s failed validation for tag %q", fe.Field(), fe.Tag())
+	}
+}
@@ -0,0 +1,120 @@
+package pb
+
+import "github.com/vibtellect/benchmark-go-lambda/internal/models"
+
+// FromItem converts a models.Item to its protobuf message.
+func FromItem(item models.Item) *Item {
+	return &Item{
+		Id:          item.ID,
+		Name:        item.Name,
+		Description: item.Description,
+		Price:       item.Price,
+		Category:    item.Category,
+		Labels:      item.Labels,
+		Archived:    item.Archived,
+		OwnerId:     item.OwnerID,
+		CreatedAt:   item.CreatedAt,
+		UpdatedAt:   item.UpdatedAt,
+		Version:     item.Version,
+	}
+}
+
+// ToItem converts m back to a models.Item.
+func ToItem(m *Item) models.Item {
+	return models.Item{
+		ID:          m.Id,
+		Name:        m.Name,
+		Description: m.Description,
+		Price:       m.Price,
+		Category:    m.Category,
+		Labels:      m.Labels,
+		Archived:    m.Archived,
+		OwnerID:     m.OwnerId,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+		Version:     m.Version,
+	}
+}
+
+// FromItemCreate converts a models.ItemCreate to its protobuf message.
+func FromItemCreate(in models.ItemCreate) *ItemCreate {
+	return &ItemCreate{
+		Name:        in.Name,
+		Description: in.Description,
+		Price:       in.Price,
+		Category:    in.Category,
+		Labels:      in.Labels,
+		Archived:    in.Archived,
+	}
+}
+
+// ToItemCreate converts m back to a models.ItemCreate.
+func ToItemCreate(m *ItemCreate) models.ItemCreate {
+	return models.ItemCreate{
+		Name:        m.Name,
+		Description: m.Description,
+		Price:       m.Price,
+		Category:    m.Category,
+		Labels:      m.Labels,
+		Archived:    m.Archived,
+	}
+}
+
+// FromItemUpdate converts a models.ItemUpdate to its protobuf message.
+func FromItemUpdate(in models.ItemUpdate) *ItemUpdate {
+	return &ItemUpdate{
+		Name:        in.Name,
+		Description: in.Description,
+		Price:       in.Price,
+		Category:    in.Category,
+		Labels:      in.Labels,
+		Archived:    in.Archived,
+		Version:     in.Version,
+	}
+}
+
+// ToItemUpdate converts m back to a models.ItemUpdate.
+func ToItemUpdate(m *ItemUpdate) models.ItemUpdate {
+	return models.ItemUpdate{
+		Name:        m.Name,
+		Description: m.Description,
+		Price:       m.Price,
+		Category:    m.Category,
+		Labels:      m.Labels,
+		Archived:    m.Archived,
+		Version:     m.Version,
+	}
+}
+
+// FromItemResponse converts a models.ItemResponse to its protobuf message.
+func FromItemResponse(in models.ItemResponse) *ItemResponse {
+	out := &ItemResponse{Success: in.Success, Message: in.Message}
+	if in.Data != nil {
+		out.Data = FromItem(*in.Data)
+	}
+	return out
+}
+
+// FromItemListResponse converts a models.ItemListResponse to its protobuf
+// message.
+func FromItemListResponse(in models.ItemListResponse) *ItemListResponse {
+	data := make([]Item, len(in.Data))
+	for i, item := range in.Data {
+		data[i] = *FromItem(item)
+	}
+	return &ItemListResponse{
+		Success:    in.Success,
+		Data:       data,
+		Count:      int32(in.Count),
+		Total:      int32(in.Total),
+		Page:       int32(in.Page),
+		PageSize:   int32(in.PageSize),
+		NextCursor: in.NextCursor,
+		Message:    in.Message,
+	}
+}
+
+// FromErrorResponse converts a models.ErrorResponse to its protobuf message.
+func FromErrorResponse(in models.ErrorResponse) *ErrorResponse {
+	return &ErrorResponse{Success: in.Success, Message: in.Message, Error: in.Error}
+}
@@ -0,0 +1,32 @@
+package pb
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vibtellect/benchmark-go-lambda/internal/models"
+)
+
+func TestFromItemToItemRoundTrip(t *testing.T) {
+	item := models.Item{
+		ID:        "item-1",
+		Name:      "Widget",
+		Price:     9.99,
+		OwnerID:   "user-1",
+		CreatedAt: 1000,
+		UpdatedAt: 2000,
+		Version:   1,
+	}
+
+	got := ToItem(FromItem(item))
+	if !reflect.DeepEqual(got, item) {
+		t.Errorf("Expected %+v, got %+v", item, got)
+	}
+}
+
+func TestFromItemResponseOmitsDataWhenNil(t *testing.T) {
+	out := FromItemResponse(models.ItemResponse{Success: false, Message: "not found"})
+	if out.Data != nil {
+		t.Errorf("Expected nil Data for a response with no item, got %+v", out.Data)
+	}
+}
@@ -0,0 +1,460 @@
+// Package pb is a minimal, hand-written protobuf wire-format codec for the
+// message types defined in proto/items.proto. It does not implement the
+// full proto.Message/protoreflect interface protoc-gen-go would produce -
+// generating that needs a real `protoc` run, which this build doesn't have
+// - so each type below exposes a plain Marshal/Unmarshal method pair
+// instead of plugging into google.golang.org/protobuf's reflection-based
+// (un)marshaler. The wire format and field numbers match proto/items.proto
+// exactly, so this package is a drop-in replacement target once the build
+// picks up a protoc step.
+package pb
+
+import (
+	"fmt"
+	"math"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Item mirrors the Item message in proto/items.proto.
+type Item struct {
+	Id          string
+	Name        string
+	Description string
+	Price       float64
+	Category    string
+	Labels      []string
+	Archived    bool
+	OwnerId     string
+	CreatedAt   int64
+	UpdatedAt   int64
+	Version     int64
+}
+
+// Marshal encodes m in protobuf wire format. Proto3 fields at their zero
+// value are omitted, same as protoc-generated code would.
+func (m *Item) Marshal() []byte {
+	var b []byte
+	b = appendString(b, 1, m.Id)
+	b = appendString(b, 2, m.Name)
+	b = appendString(b, 3, m.Description)
+	b = appendDouble(b, 4, m.Price)
+	b = appendString(b, 5, m.Category)
+	for _, label := range m.Labels {
+		b = appendString(b, 6, label)
+	}
+	b = appendBool(b, 7, m.Archived)
+	b = appendString(b, 8, m.OwnerId)
+	b = appendInt64(b, 9, m.CreatedAt)
+	b = appendInt64(b, 10, m.UpdatedAt)
+	b = appendInt64(b, 11, m.Version)
+	return b
+}
+
+// Unmarshal decodes b, previously produced by Marshal, into m.
+func (m *Item) Unmarshal(b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch num {
+		case 1:
+			return consumeStringInto(&m.Id, typ, b)
+		case 2:
+			return consumeStringInto(&m.Name, typ, b)
+		case 3:
+			return consumeStringInto(&m.Description, typ, b)
+		case 4:
+			return consumeDoubleInto(&m.Price, typ, b)
+		case 5:
+			return consumeStringInto(&m.Category, typ, b)
+		case 6:
+			var label string
+			n, err := consumeStringInto(&label, typ, b)
+			m.Labels = append(m.Labels, label)
+			return n, err
+		case 7:
+			return consumeBoolInto(&m.Archived, typ, b)
+		case 8:
+			return consumeStringInto(&m.OwnerId, typ, b)
+		case 9:
+			return consumeInt64Into(&m.CreatedAt, typ, b)
+		case 10:
+			return consumeInt64Into(&m.UpdatedAt, typ, b)
+		case 11:
+			return consumeInt64Into(&m.Version, typ, b)
+		default:
+			return skipField(typ, b)
+		}
+	})
+}
+
+// ItemCreate mirrors the ItemCreate message in proto/items.proto.
+type ItemCreate struct {
+	Name        string
+	Description string
+	Price       float64
+	Category    string
+	Labels      []string
+	Archived    bool
+}
+
+func (m *ItemCreate) Marshal() []byte {
+	var b []byte
+	b = appendString(b, 1, m.Name)
+	b = appendString(b, 2, m.Description)
+	b = appendDouble(b, 3, m.Price)
+	b = appendString(b, 4, m.Category)
+	for _, label := range m.Labels {
+		b = appendString(b, 5, label)
+	}
+	b = appendBool(b, 6, m.Archived)
+	return b
+}
+
+func (m *ItemCreate) Unmarshal(b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch num {
+		case 1:
+			return consumeStringInto(&m.Name, typ, b)
+		case 2:
+			return consumeStringInto(&m.Description, typ, b)
+		case 3:
+			return consumeDoubleInto(&m.Price, typ, b)
+		case 4:
+			return consumeStringInto(&m.Category, typ, b)
+		case 5:
+			var label string
+			n, err := consumeStringInto(&label, typ, b)
+			m.Labels = append(m.Labels, label)
+			return n, err
+		case 6:
+			return consumeBoolInto(&m.Archived, typ, b)
+		default:
+			return skipField(typ, b)
+		}
+	})
+}
+
+// ItemUpdate mirrors the ItemUpdate message in proto/items.proto. Every
+// scalar field is a pointer: nil means "field absent" (leave unchanged),
+// matching models.ItemUpdate and proto3's explicit field presence for
+// `optional` fields.
+type ItemUpdate struct {
+	Name        *string
+	Description *string
+	Price       *float64
+	Category    *string
+	Labels      []string
+	Archived    *bool
+	Version     *int64
+}
+
+func (m *ItemUpdate) Marshal() []byte {
+	var b []byte
+	if m.Name != nil {
+		b = appendString(b, 1, *m.Name)
+	}
+	if m.Description != nil {
+		b = appendString(b, 2, *m.Description)
+	}
+	if m.Price != nil {
+		b = appendDouble(b, 3, *m.Price)
+	}
+	if m.Category != nil {
+		b = appendString(b, 4, *m.Category)
+	}
+	for _, label := range m.Labels {
+		b = appendString(b, 5, label)
+	}
+	if m.Archived != nil {
+		b = appendBool(b, 6, *m.Archived)
+	}
+	if m.Version != nil {
+		b = appendInt64(b, 7, *m.Version)
+	}
+	return b
+}
+
+func (m *ItemUpdate) Unmarshal(b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch num {
+		case 1:
+			m.Name = new(string)
+			return consumeStringInto(m.Name, typ, b)
+		case 2:
+			m.Description = new(string)
+			return consumeStringInto(m.Description, typ, b)
+		case 3:
+			m.Price = new(float64)
+			return consumeDoubleInto(m.Price, typ, b)
+		case 4:
+			m.Category = new(string)
+			return consumeStringInto(m.Category, typ, b)
+		case 5:
+			var label string
+			n, err := consumeStringInto(&label, typ, b)
+			m.Labels = append(m.Labels, label)
+			return n, err
+		case 6:
+			m.Archived = new(bool)
+			return consumeBoolInto(m.Archived, typ, b)
+		case 7:
+			m.Version = new(int64)
+			return consumeInt64Into(m.Version, typ, b)
+		default:
+			return skipField(typ, b)
+		}
+	})
+}
+
+// ItemResponse mirrors the ItemResponse message in proto/items.proto.
+type ItemResponse struct {
+	Success bool
+	Data    *Item
+	Message string
+}
+
+func (m *ItemResponse) Marshal() []byte {
+	var b []byte
+	b = appendBool(b, 1, m.Success)
+	if m.Data != nil {
+		b = appendMessage(b, 2, m.Data)
+	}
+	b = appendString(b, 3, m.Message)
+	return b
+}
+
+func (m *ItemResponse) Unmarshal(b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch num {
+		case 1:
+			return consumeBoolInto(&m.Success, typ, b)
+		case 2:
+			m.Data = &Item{}
+			return consumeMessageInto(m.Data, typ, b)
+		case 3:
+			return consumeStringInto(&m.Message, typ, b)
+		default:
+			return skipField(typ, b)
+		}
+	})
+}
+
+// ItemListResponse mirrors the ItemListResponse message in proto/items.proto.
+type ItemListResponse struct {
+	Success    bool
+	Data       []Item
+	Count      int32
+	Total      int32
+	Page       int32
+	PageSize   int32
+	NextCursor string
+	Message    string
+}
+
+func (m *ItemListResponse) Marshal() []byte {
+	var b []byte
+	b = appendBool(b, 1, m.Success)
+	for i := range m.Data {
+		b = appendMessage(b, 2, &m.Data[i])
+	}
+	b = appendInt32(b, 3, m.Count)
+	b = appendInt32(b, 4, m.Total)
+	b = appendInt32(b, 5, m.Page)
+	b = appendInt32(b, 6, m.PageSize)
+	b = appendString(b, 7, m.NextCursor)
+	b = appendString(b, 8, m.Message)
+	return b
+}
+
+func (m *ItemListResponse) Unmarshal(b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch num {
+		case 1:
+			return consumeBoolInto(&m.Success, typ, b)
+		case 2:
+			var item Item
+			n, err := consumeMessageInto(&item, typ, b)
+			m.Data = append(m.Data, item)
+			return n, err
+		case 3:
+			return consumeInt32Into(&m.Count, typ, b)
+		case 4:
+			return consumeInt32Into(&m.Total, typ, b)
+		case 5:
+			return consumeInt32Into(&m.Page, typ, b)
+		case 6:
+			return consumeInt32Into(&m.PageSize, typ, b)
+		case 7:
+			return consumeStringInto(&m.NextCursor, typ, b)
+		case 8:
+			return consumeStringInto(&m.Message, typ, b)
+		default:
+			return skipField(typ, b)
+		}
+	})
+}
+
+// ErrorResponse mirrors the ErrorResponse message in proto/items.proto.
+// Per-field validation detail (models.ErrorResponse.Fields) isn't carried
+// over protobuf yet - see proto/items.proto.
+type ErrorResponse struct {
+	Success bool
+	Message string
+	Error   string
+}
+
+func (m *ErrorResponse) Marshal() []byte {
+	var b []byte
+	b = appendBool(b, 1, m.Success)
+	b = appendString(b, 2, m.Message)
+	b = appendString(b, 3, m.Error)
+	return b
+}
+
+func (m *ErrorResponse) Unmarshal(b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch num {
+		case 1:
+			return consumeBoolInto(&m.Success, typ, b)
+		case 2:
+			return consumeStringInto(&m.Message, typ, b)
+		case 3:
+			return consumeStringInto(&m.Error, typ, b)
+		default:
+			return skipField(typ, b)
+		}
+	})
+}
+
+// message is implemented by every type in this file that can be encoded as
+// a protobuf sub-message (i.e. everything but the scalar append/consume
+// helpers below).
+type message interface {
+	Marshal() []byte
+}
+
+func appendMessage(b []byte, num protowire.Number, m message) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, m.Marshal())
+}
+
+func appendString(b []byte, num protowire.Number, v string) []byte {
+	if v == "" {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, v)
+}
+
+func appendBool(b []byte, num protowire.Number, v bool) []byte {
+	if !v {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, 1)
+}
+
+func appendInt64(b []byte, num protowire.Number, v int64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, uint64(v))
+}
+
+func appendInt32(b []byte, num protowire.Number, v int32) []byte {
+	return appendInt64(b, num, int64(v))
+}
+
+func appendDouble(b []byte, num protowire.Number, v float64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.Fixed64Type)
+	return protowire.AppendFixed64(b, math.Float64bits(v))
+}
+
+// consumeFields walks every (field number, wire type, value) triple in b,
+// dispatching each to fn, until b is exhausted. fn returns how many bytes of
+// the value it consumed (not including the tag), mirroring protowire's
+// Consume* functions.
+func consumeFields(b []byte, fn func(num protowire.Number, typ protowire.Type, b []byte) (int, error)) error {
+	for len(b) > 0 {
+		num, typ, tagLen := protowire.ConsumeTag(b)
+		if tagLen < 0 {
+			return fmt.Errorf("pb: invalid tag: %w", protowire.ParseError(tagLen))
+		}
+		b = b[tagLen:]
+
+		n, err := fn(num, typ, b)
+		if err != nil {
+			return err
+		}
+		if n < 0 {
+			return fmt.Errorf("pb: invalid field %d value", num)
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+func skipField(typ protowire.Type, b []byte) (int, error) {
+	n := protowire.ConsumeFieldValue(0, typ, b)
+	if n < 0 {
+		return 0, fmt.Errorf("pb: invalid field value: %w", protowire.ParseError(n))
+	}
+	return n, nil
+}
+
+func consumeStringInto(dst *string, typ protowire.Type, b []byte) (int, error) {
+	v, n := protowire.ConsumeString(b)
+	if n < 0 {
+		return 0, fmt.Errorf("pb: invalid string: %w", protowire.ParseError(n))
+	}
+	*dst = v
+	return n, nil
+}
+
+func consumeBoolInto(dst *bool, typ protowire.Type, b []byte) (int, error) {
+	v, n := protowire.ConsumeVarint(b)
+	if n < 0 {
+		return 0, fmt.Errorf("pb: invalid bool: %w", protowire.ParseError(n))
+	}
+	*dst = v != 0
+	return n, nil
+}
+
+func consumeInt64Into(dst *int64, typ protowire.Type, b []byte) (int, error) {
+	v, n := protowire.ConsumeVarint(b)
+	if n < 0 {
+		return 0, fmt.Errorf("pb: invalid int64: %w", protowire.ParseError(n))
+	}
+	*dst = int64(v)
+	return n, nil
+}
+
+func consumeInt32Into(dst *int32, typ protowire.Type, b []byte) (int, error) {
+	var v int64
+	n, err := consumeInt64Into(&v, typ, b)
+	*dst = int32(v)
+	return n, err
+}
+
+func consumeDoubleInto(dst *float64, typ protowire.Type, b []byte) (int, error) {
+	v, n := protowire.ConsumeFixed64(b)
+	if n < 0 {
+		return 0, fmt.Errorf("pb: invalid double: %w", protowire.ParseError(n))
+	}
+	*dst = math.Float64frombits(v)
+	return n, nil
+}
+
+func consumeMessageInto(dst *Item, typ protowire.Type, b []byte) (int, error) {
+	v, n := protowire.ConsumeBytes(b)
+	if n < 0 {
+		return 0, fmt.Errorf("pb: invalid embedded message: %w", protowire.ParseError(n))
+	}
+	if err := dst.Unmarshal(v); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
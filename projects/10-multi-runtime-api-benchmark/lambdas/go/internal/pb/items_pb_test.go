@@ -0,0 +1,134 @@
+package pb
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestItemMarshalUnmarshalRoundTrip(t *testing.T) {
+	in := &Item{
+		Id:          "item-1",
+		Name:        "Widget",
+		Description: "A widget",
+		Price:       9.99,
+		Category:    "tools",
+		Labels:      []string{"a", "b"},
+		Archived:    true,
+		OwnerId:     "user-1",
+		CreatedAt:   1000,
+		UpdatedAt:   2000,
+		Version:     3,
+	}
+
+	var out Item
+	if err := out.Unmarshal(in.Marshal()); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if !reflect.DeepEqual(out, *in) {
+		t.Errorf("Expected round-tripped item %+v, got %+v", *in, out)
+	}
+}
+
+func TestItemMarshalOmitsZeroValues(t *testing.T) {
+	b := (&Item{}).Marshal()
+	if len(b) != 0 {
+		t.Errorf("Expected zero-value Item to marshal to no bytes, got %d bytes", len(b))
+	}
+}
+
+func TestItemCreateMarshalUnmarshalRoundTrip(t *testing.T) {
+	in := &ItemCreate{
+		Name:        "Widget",
+		Description: "A widget",
+		Price:       9.99,
+		Category:    "tools",
+		Labels:      []string{"a", "b"},
+		Archived:    true,
+	}
+
+	var out ItemCreate
+	if err := out.Unmarshal(in.Marshal()); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if out.Name != in.Name || out.Price != in.Price || len(out.Labels) != 2 {
+		t.Errorf("Expected round-tripped ItemCreate %+v, got %+v", *in, out)
+	}
+}
+
+func TestItemUpdateMarshalUnmarshalOnlySetsPresentFields(t *testing.T) {
+	name := "New Name"
+	in := &ItemUpdate{Name: &name}
+
+	var out ItemUpdate
+	if err := out.Unmarshal(in.Marshal()); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if out.Name == nil || *out.Name != name {
+		t.Fatalf("Expected Name to round-trip, got %+v", out)
+	}
+	if out.Description != nil || out.Price != nil || out.Archived != nil || out.Version != nil {
+		t.Errorf("Expected unset fields to stay nil, got %+v", out)
+	}
+}
+
+func TestItemResponseMarshalUnmarshalRoundTrip(t *testing.T) {
+	in := &ItemResponse{
+		Success: true,
+		Data:    &Item{Id: "item-1", Name: "Widget"},
+		Message: "ok",
+	}
+
+	var out ItemResponse
+	if err := out.Unmarshal(in.Marshal()); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if out.Success != in.Success || out.Message != in.Message {
+		t.Fatalf("Expected round-tripped envelope fields, got %+v", out)
+	}
+	if out.Data == nil || out.Data.Id != in.Data.Id || out.Data.Name != in.Data.Name {
+		t.Errorf("Expected embedded Data to round-trip, got %+v", out.Data)
+	}
+}
+
+func TestItemListResponseMarshalUnmarshalRoundTrip(t *testing.T) {
+	in := &ItemListResponse{
+		Success:    true,
+		Data:       []Item{{Id: "1", Name: "A"}, {Id: "2", Name: "B"}},
+		Count:      2,
+		Total:      10,
+		Page:       1,
+		PageSize:   2,
+		NextCursor: "cursor-abc",
+		Message:    "ok",
+	}
+
+	var out ItemListResponse
+	if err := out.Unmarshal(in.Marshal()); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if out.Count != in.Count || out.Total != in.Total || out.NextCursor != in.NextCursor {
+		t.Fatalf("Expected round-tripped envelope fields, got %+v", out)
+	}
+	if len(out.Data) != 2 || out.Data[0].Id != "1" || out.Data[1].Id != "2" {
+		t.Errorf("Expected both Data items to round-trip in order, got %+v", out.Data)
+	}
+}
+
+func TestErrorResponseMarshalUnmarshalRoundTrip(t *testing.T) {
+	in := &ErrorResponse{Success: false, Message: "bad request", Error: "name is required"}
+
+	var out ErrorResponse
+	if err := out.Unmarshal(in.Marshal()); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if out != *in {
+		t.Errorf("Expected round-tripped error %+v, got %+v", *in, out)
+	}
+}
+
+func TestUnmarshalInvalidBytesReturnsError(t *testing.T) {
+	var out Item
+	if err := out.Unmarshal([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}); err == nil {
+		t.Error("Expected an error decoding malformed bytes, got nil")
+	}
+}
@@ -0,0 +1,99 @@
+// Package transport wires HTTP content negotiation into the item handlers:
+// a caller's Accept/Content-Type header picks JSON, MessagePack, or
+// Protobuf for the response/request body, so the benchmark has a
+// serialization-cost axis to compare against the other runtimes.
+package transport
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/vibtellect/benchmark-go-lambda/internal/models"
+	"github.com/vibtellect/benchmark-go-lambda/internal/pb"
+)
+
+// Respond serializes payload as JSON, MessagePack, or Protobuf depending on
+// the request's Accept header, defaulting to JSON when Accept is absent or
+// matches none of the three. Protobuf is only available for the payload
+// types proto/items.proto defines (Item/ItemResponse/ItemListResponse/
+// ErrorResponse); any other payload type falls back to JSON even when the
+// caller asked for protobuf, since there's no generated message for it.
+func Respond(c *gin.Context, status int, payload interface{}) {
+	switch c.NegotiateFormat(binding.MIMEJSON, binding.MIMEMSGPACK, binding.MIMEPROTOBUF) {
+	case binding.MIMEMSGPACK:
+		c.MsgPack(status, payload)
+	case binding.MIMEPROTOBUF:
+		if msg, ok := toProtoMessage(payload); ok {
+			c.Data(status, binding.MIMEPROTOBUF, msg.Marshal())
+			return
+		}
+		c.JSON(status, payload)
+	default:
+		c.JSON(status, payload)
+	}
+}
+
+// protoMessage is implemented by every generated type in internal/pb.
+type protoMessage interface {
+	Marshal() []byte
+}
+
+func toProtoMessage(payload interface{}) (protoMessage, bool) {
+	switch v := payload.(type) {
+	case models.ItemResponse:
+		return pb.FromItemResponse(v), true
+	case models.ItemListResponse:
+		return pb.FromItemListResponse(v), true
+	case models.ErrorResponse:
+		return pb.FromErrorResponse(v), true
+	case models.Item:
+		return pb.FromItem(v), true
+	default:
+		return nil, false
+	}
+}
+
+// Bind decodes the request body into obj, a *models.ItemCreate or
+// *models.ItemUpdate, using the format named by Content-Type (JSON,
+// MessagePack, or Protobuf), mirroring Respond's negotiation on the way in.
+// Struct-tag `binding` validation runs for every format, same as
+// c.ShouldBindJSON already does for the JSON path.
+func Bind(c *gin.Context, obj interface{}) error {
+	switch c.ContentType() {
+	case binding.MIMEMSGPACK:
+		return c.ShouldBindWith(obj, binding.MsgPack)
+	case binding.MIMEPROTOBUF:
+		return bindProtobuf(c, obj)
+	default:
+		return c.ShouldBindJSON(obj)
+	}
+}
+
+func bindProtobuf(c *gin.Context, obj interface{}) error {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return fmt.Errorf("reading protobuf body: %w", err)
+	}
+
+	switch v := obj.(type) {
+	case *models.ItemCreate:
+		var m pb.ItemCreate
+		if err := m.Unmarshal(body); err != nil {
+			return err
+		}
+		*v = pb.ToItemCreate(&m)
+	case *models.ItemUpdate:
+		var m pb.ItemUpdate
+		if err := m.Unmarshal(body); err != nil {
+			return err
+		}
+		*v = pb.ToItemUpdate(&m)
+	default:
+		return fmt.Errorf("protobuf binding not supported for %T", obj)
+	}
+
+	return binding.Validator.ValidateStruct(obj)
+}
@@ -0,0 +1,315 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/uuid"
+	"github.com/vibtellect/benchmark-go-lambda/internal/models"
+)
+
+// dynamoDBBatchWriteLimit is the maximum number of items DynamoDB accepts
+// in a single BatchWriteItem call.
+const dynamoDBBatchWriteLimit = 25
+
+// dynamoDBBatchGetLimit is the maximum number of keys DynamoDB accepts in a
+// single BatchGetItem call.
+const dynamoDBBatchGetLimit = 100
+
+// maxBatchRetries bounds how many times withBackoff retries unprocessed
+// items/keys before giving up.
+const maxBatchRetries = 5
+
+// dynamoDBTransactLimit is the maximum number of actions DynamoDB accepts in
+// a single TransactWriteItems call. Unlike BatchWriteItem/BatchGetItem, a
+// transaction can't be chunked across calls without losing its atomicity
+// guarantee, so TransactItems rejects requests over this limit outright.
+const dynamoDBTransactLimit = 100
+
+// BatchCreateItems creates up to 25 items per DynamoDB request, chunking
+// larger inputs and retrying any UnprocessedItems with exponential backoff.
+// Every created item is owned by ownerID, same as CreateItem.
+func (db *DynamoDBClient) BatchCreateItems(ctx context.Context, itemsData []models.ItemCreate, ownerID string) ([]models.Item, error) {
+	created := make([]models.Item, 0, len(itemsData))
+
+	for start := 0; start < len(itemsData); start += dynamoDBBatchWriteLimit {
+		end := start + dynamoDBBatchWriteLimit
+		if end > len(itemsData) {
+			end = len(itemsData)
+		}
+
+		chunk := itemsData[start:end]
+		chunkItems := make([]models.Item, len(chunk))
+		writeRequests := make([]types.WriteRequest, len(chunk))
+
+		currentTime := models.CurrentTimestamp()
+		for i, itemData := range chunk {
+			item := models.Item{
+				ID:          uuid.New().String(),
+				Name:        itemData.Name,
+				Description: itemData.Description,
+				Price:       itemData.Price,
+				Category:    itemData.Category,
+				Labels:      itemData.Labels,
+				Archived:    itemData.Archived,
+				OwnerID:     ownerID,
+				CreatedAt:   currentTime,
+				UpdatedAt:   currentTime,
+				Version:     1,
+				ItemType:    itemTypePartition,
+			}
+			chunkItems[i] = item
+
+			av, err := marshalMap(item)
+			if err != nil {
+				return created, fmt.Errorf("marshaling item %d: %w", start+i, err)
+			}
+			writeRequests[i] = types.WriteRequest{PutRequest: &types.PutRequest{Item: av}}
+		}
+
+		if err := db.batchWriteWithRetry(ctx, writeRequests); err != nil {
+			return created, err
+		}
+
+		created = append(created, chunkItems...)
+	}
+
+	log.Printf("Batch created %d items", len(created))
+	return created, nil
+}
+
+func (db *DynamoDBClient) batchWriteWithRetry(ctx context.Context, requests []types.WriteRequest) error {
+	pending := requests
+
+	return withBackoff(ctx, maxBatchRetries, func(attemptNum int) (bool, error) {
+		db.recordBackendCall()
+		result, err := db.client.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+			RequestItems: map[string][]types.WriteRequest{db.tableName: pending},
+		})
+		if err != nil {
+			return false, err
+		}
+
+		unprocessed := result.UnprocessedItems[db.tableName]
+		if len(unprocessed) == 0 {
+			return true, nil
+		}
+
+		log.Printf("BatchWriteItem attempt %d: %d unprocessed items, retrying", attemptNum+1, len(unprocessed))
+		pending = unprocessed
+		return false, nil
+	})
+}
+
+// BatchDeleteItems deletes up to 25 items per DynamoDB request by ID,
+// chunking larger inputs and retrying any UnprocessedItems with exponential
+// backoff. It doesn't check for existence first, so deleting an already-gone
+// ID is not an error.
+func (db *DynamoDBClient) BatchDeleteItems(ctx context.Context, ids []string) error {
+	for start := 0; start < len(ids); start += dynamoDBBatchWriteLimit {
+		end := start + dynamoDBBatchWriteLimit
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		chunk := ids[start:end]
+		writeRequests := make([]types.WriteRequest, len(chunk))
+		for i, id := range chunk {
+			writeRequests[i] = types.WriteRequest{
+				DeleteRequest: &types.DeleteRequest{
+					Key: map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: id}},
+				},
+			}
+		}
+
+		if err := db.batchWriteWithRetry(ctx, writeRequests); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("Batch deleted %d items", len(ids))
+	return nil
+}
+
+// BatchGetItems fetches items by ID, chunking into groups of 100 and
+// retrying any UnprocessedKeys with exponential backoff. ids with no
+// matching item are returned in the missing slice, same as ids whose item
+// exists but belongs to someone else when ownerID is non-empty - same
+// not-found-not-403 treatment GetItem gives a mismatched owner.
+func (db *DynamoDBClient) BatchGetItems(ctx context.Context, ids []string, ownerID string) (items []models.Item, missing []string, err error) {
+	found := make(map[string]models.Item, len(ids))
+
+	for start := 0; start < len(ids); start += dynamoDBBatchGetLimit {
+		end := start + dynamoDBBatchGetLimit
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		keys := make([]map[string]types.AttributeValue, end-start)
+		for i, id := range ids[start:end] {
+			keys[i] = map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: id}}
+		}
+
+		if err := db.batchGetWithRetry(ctx, keys, found); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	items = make([]models.Item, 0, len(found))
+	for _, id := range ids {
+		if item, ok := found[id]; ok && (ownerID == "" || item.OwnerID == ownerID) {
+			items = append(items, item)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+
+	log.Printf("Batch got %d items (%d missing)", len(items), len(missing))
+	return items, missing, nil
+}
+
+func (db *DynamoDBClient) batchGetWithRetry(ctx context.Context, keys []map[string]types.AttributeValue, found map[string]models.Item) error {
+	pending := keys
+
+	return withBackoff(ctx, maxBatchRetries, func(attemptNum int) (bool, error) {
+		db.recordBackendCall()
+		result, err := db.client.BatchGetItem(ctx, &dynamodb.BatchGetItemInput{
+			RequestItems: map[string]types.KeysAndAttributes{
+				db.tableName: {Keys: pending},
+			},
+		})
+		if err != nil {
+			return false, err
+		}
+
+		for _, av := range result.Responses[db.tableName] {
+			var item models.Item
+			if err := unmarshalMap(av, &item); err != nil {
+				log.Printf("Error unmarshaling batch-got item: %v", err)
+				continue
+			}
+			found[item.ID] = item
+		}
+
+		unprocessed := result.UnprocessedKeys[db.tableName].Keys
+		if len(unprocessed) == 0 {
+			return true, nil
+		}
+
+		log.Printf("BatchGetItem attempt %d: %d unprocessed keys, retrying", attemptNum+1, len(unprocessed))
+		pending = unprocessed
+		return false, nil
+	})
+}
+
+// TransactItems translates a heterogeneous list of put/update/delete/
+// condition-check operations into a single DynamoDB TransactWriteItems call,
+// which succeeds or fails atomically. When ownerID is non-empty, every
+// update/delete/condition-check op is conditioned on owner_id = ownerID
+// (put needs no such check - it only ever creates a new item, owned by
+// ownerID): update with owner_id = :owner_id, delete and condition-check
+// with the same attribute_exists(id) AND owner_id = :owner_id condition
+// bulkDeleteAtomic uses, so a transaction touching another owner's item
+// fails the whole batch instead of silently crossing tenants.
+func (db *DynamoDBClient) TransactItems(ctx context.Context, ops []models.TransactOp, ownerID string) error {
+	if len(ops) > dynamoDBTransactLimit {
+		return fmt.Errorf("transaction has %d operations, exceeds DynamoDB's %d-action limit", len(ops), dynamoDBTransactLimit)
+	}
+
+	items := make([]types.TransactWriteItem, len(ops))
+
+	for i, op := range ops {
+		switch op.Op {
+		case "put":
+			if op.Item == nil {
+				return fmt.Errorf("operation %d: put requires an item", i)
+			}
+			currentTime := models.CurrentTimestamp()
+			item := models.Item{
+				ID:          uuid.New().String(),
+				Name:        op.Item.Name,
+				Description: op.Item.Description,
+				Price:       op.Item.Price,
+				OwnerID:     ownerID,
+				CreatedAt:   currentTime,
+				UpdatedAt:   currentTime,
+				ItemType:    itemTypePartition,
+			}
+			av, err := marshalMap(item)
+			if err != nil {
+				return fmt.Errorf("operation %d: marshaling item: %w", i, err)
+			}
+			items[i] = types.TransactWriteItem{Put: &types.Put{TableName: aws.String(db.tableName), Item: av}}
+
+		case "update":
+			if op.Update == nil {
+				return fmt.Errorf("operation %d: update requires fields", i)
+			}
+			expr, names, values := buildUpdateExpression(*op.Update)
+			update := &types.Update{
+				TableName:                 aws.String(db.tableName),
+				Key:                       map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: op.ID}},
+				UpdateExpression:          aws.String(expr),
+				ExpressionAttributeValues: values,
+			}
+			if ownerID != "" {
+				update.ConditionExpression = aws.String("owner_id = :owner_id")
+				update.ExpressionAttributeValues[":owner_id"] = &types.AttributeValueMemberS{Value: ownerID}
+			}
+			if len(names) > 0 {
+				update.ExpressionAttributeNames = names
+			}
+			items[i] = types.TransactWriteItem{Update: update}
+
+		case "delete":
+			del := &types.Delete{
+				TableName: aws.String(db.tableName),
+				Key:       map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: op.ID}},
+			}
+			if ownerID != "" {
+				del.ConditionExpression = aws.String("attribute_exists(id) AND owner_id = :owner_id")
+				del.ExpressionAttributeValues = map[string]types.AttributeValue{
+					":owner_id": &types.AttributeValueMemberS{Value: ownerID},
+				}
+			}
+			items[i] = types.TransactWriteItem{Delete: del}
+
+		case "condition-check":
+			check := &types.ConditionCheck{
+				TableName:           aws.String(db.tableName),
+				Key:                 map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: op.ID}},
+				ConditionExpression: aws.String("attribute_exists(id)"),
+			}
+			if ownerID != "" {
+				check.ConditionExpression = aws.String("attribute_exists(id) AND owner_id = :owner_id")
+				check.ExpressionAttributeValues = map[string]types.AttributeValue{
+					":owner_id": &types.AttributeValueMemberS{Value: ownerID},
+				}
+			}
+			items[i] = types.TransactWriteItem{ConditionCheck: check}
+
+		default:
+			return fmt.Errorf("operation %d: unknown op %q", i, op.Op)
+		}
+	}
+
+	err := withBackoff(ctx, maxBatchRetries, func(attemptNum int) (bool, error) {
+		db.recordBackendCall()
+		_, err := db.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: items})
+		if err != nil {
+			return false, err
+		}
+		return true, nil
+	})
+	if err != nil {
+		log.Printf("Error executing transaction: %v", err)
+		return err
+	}
+
+	log.Printf("Transaction with %d operations committed", len(ops))
+	return nil
+}
@@ -0,0 +1,264 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/vibtellect/benchmark-go-lambda/internal/models"
+)
+
+func TestBatchCreateItemsChunks(t *testing.T) {
+	var calls int
+	api := &mockDynamoDBAPI{
+		batchWriteItemFn: func(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+			calls++
+			return &dynamodb.BatchWriteItemOutput{}, nil
+		},
+	}
+	client := NewDynamoDBClientWithAPI(api, "test-table")
+
+	itemsData := make([]models.ItemCreate, 30)
+	for i := range itemsData {
+		itemsData[i] = models.ItemCreate{Name: "Item", Price: 1.0}
+	}
+
+	created, err := client.BatchCreateItems(context.Background(), itemsData, "owner-1")
+	if err != nil {
+		t.Fatalf("BatchCreateItems returned error: %v", err)
+	}
+	if len(created) != 30 {
+		t.Errorf("Expected 30 created items, got %d", len(created))
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 BatchWriteItem calls for 30 items (25 limit), got %d", calls)
+	}
+}
+
+func TestBatchCreateItemsRetriesUnprocessed(t *testing.T) {
+	var calls int
+	api := &mockDynamoDBAPI{
+		batchWriteItemFn: func(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+			calls++
+			if calls == 1 {
+				return &dynamodb.BatchWriteItemOutput{
+					UnprocessedItems: map[string][]types.WriteRequest{
+						"test-table": params.RequestItems["test-table"][:1],
+					},
+				}, nil
+			}
+			return &dynamodb.BatchWriteItemOutput{}, nil
+		},
+	}
+	client := NewDynamoDBClientWithAPI(api, "test-table")
+
+	_, err := client.BatchCreateItems(context.Background(), []models.ItemCreate{{Name: "A", Price: 1}, {Name: "B", Price: 2}}, "owner-1")
+	if err != nil {
+		t.Fatalf("BatchCreateItems returned error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected a retry after unprocessed items, got %d calls", calls)
+	}
+}
+
+func TestBatchGetItemsReportsMissing(t *testing.T) {
+	api := &mockDynamoDBAPI{
+		batchGetItemFn: func(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error) {
+			av, _ := marshalMap(models.Item{ID: "found-1", Name: "Found"})
+			return &dynamodb.BatchGetItemOutput{
+				Responses: map[string][]map[string]types.AttributeValue{
+					"test-table": {av},
+				},
+			}, nil
+		},
+	}
+	client := NewDynamoDBClientWithAPI(api, "test-table")
+
+	items, missing, err := client.BatchGetItems(context.Background(), []string{"found-1", "missing-1"}, "")
+	if err != nil {
+		t.Fatalf("BatchGetItems returned error: %v", err)
+	}
+	if len(items) != 1 || items[0].ID != "found-1" {
+		t.Errorf("Expected 1 found item 'found-1', got %+v", items)
+	}
+	if len(missing) != 1 || missing[0] != "missing-1" {
+		t.Errorf("Expected 'missing-1' to be reported missing, got %+v", missing)
+	}
+}
+
+func TestBatchGetItemsExcludesOtherOwners(t *testing.T) {
+	api := &mockDynamoDBAPI{
+		batchGetItemFn: func(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error) {
+			mine, _ := marshalMap(models.Item{ID: "mine", Name: "Mine", OwnerID: "owner-1"})
+			theirs, _ := marshalMap(models.Item{ID: "theirs", Name: "Theirs", OwnerID: "owner-2"})
+			return &dynamodb.BatchGetItemOutput{
+				Responses: map[string][]map[string]types.AttributeValue{
+					"test-table": {mine, theirs},
+				},
+			}, nil
+		},
+	}
+	client := NewDynamoDBClientWithAPI(api, "test-table")
+
+	items, missing, err := client.BatchGetItems(context.Background(), []string{"mine", "theirs"}, "owner-1")
+	if err != nil {
+		t.Fatalf("BatchGetItems returned error: %v", err)
+	}
+	if len(items) != 1 || items[0].ID != "mine" {
+		t.Errorf("Expected only 'mine' to be returned, got %+v", items)
+	}
+	if len(missing) != 1 || missing[0] != "theirs" {
+		t.Errorf("Expected 'theirs' to be reported missing instead of leaking another owner's item, got %+v", missing)
+	}
+}
+
+func TestBatchDeleteItemsChunks(t *testing.T) {
+	var calls int
+	api := &mockDynamoDBAPI{
+		batchWriteItemFn: func(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+			calls++
+			return &dynamodb.BatchWriteItemOutput{}, nil
+		},
+	}
+	client := NewDynamoDBClientWithAPI(api, "test-table")
+
+	ids := make([]string, 30)
+	for i := range ids {
+		ids[i] = "id"
+	}
+
+	if err := client.BatchDeleteItems(context.Background(), ids); err != nil {
+		t.Fatalf("BatchDeleteItems returned error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 BatchWriteItem calls for 30 ids (25 limit), got %d", calls)
+	}
+}
+
+func TestBatchCreateItemsRetriesOnThrottling(t *testing.T) {
+	var calls int
+	api := &mockDynamoDBAPI{
+		batchWriteItemFn: func(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+			calls++
+			if calls == 1 {
+				return nil, &types.ProvisionedThroughputExceededException{}
+			}
+			return &dynamodb.BatchWriteItemOutput{}, nil
+		},
+	}
+	client := NewDynamoDBClientWithAPI(api, "test-table")
+
+	if _, err := client.BatchCreateItems(context.Background(), []models.ItemCreate{{Name: "A", Price: 1}}, "owner-1"); err != nil {
+		t.Fatalf("Expected throttling to be retried, got error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected a retry after throttling, got %d calls", calls)
+	}
+}
+
+func TestTransactItemsRejectsOverLimit(t *testing.T) {
+	client := NewDynamoDBClientWithAPI(&mockDynamoDBAPI{}, "test-table")
+
+	ops := make([]models.TransactOp, dynamoDBTransactLimit+1)
+	for i := range ops {
+		ops[i] = models.TransactOp{Op: "delete", ID: "id"}
+	}
+
+	if err := client.TransactItems(context.Background(), ops, ""); err == nil {
+		t.Fatal("Expected error for a transaction over the 100-action limit")
+	}
+}
+
+func TestTransactItemsDoesNotRetryConditionalCheckFailed(t *testing.T) {
+	var calls int
+	api := &mockDynamoDBAPI{
+		transactWriteItemsFn: func(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+			calls++
+			code := "ConditionalCheckFailed"
+			return nil, &types.TransactionCanceledException{
+				CancellationReasons: []types.CancellationReason{{Code: &code}},
+			}
+		},
+	}
+	client := NewDynamoDBClientWithAPI(api, "test-table")
+
+	err := client.TransactItems(context.Background(), []models.TransactOp{{Op: "delete", ID: "id"}}, "")
+	if err == nil {
+		t.Fatal("Expected TransactItems to return an error")
+	}
+	if calls != 1 {
+		t.Errorf("Expected no retry for a genuine ConditionalCheckFailed, got %d calls", calls)
+	}
+}
+
+func TestTransactItemsRejectsUnknownOp(t *testing.T) {
+	client := NewDynamoDBClientWithAPI(&mockDynamoDBAPI{}, "test-table")
+
+	err := client.TransactItems(context.Background(), []models.TransactOp{{Op: "explode", ID: "1"}}, "")
+	if err == nil {
+		t.Fatal("Expected error for unknown op")
+	}
+}
+
+func TestTransactItemsBuildsMixedOps(t *testing.T) {
+	var captured *dynamodb.TransactWriteItemsInput
+	api := &mockDynamoDBAPI{
+		transactWriteItemsFn: func(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+			captured = params
+			return &dynamodb.TransactWriteItemsOutput{}, nil
+		},
+	}
+	client := NewDynamoDBClientWithAPI(api, "test-table")
+
+	ops := []models.TransactOp{
+		{Op: "put", Item: &models.ItemCreate{Name: "New", Price: 5}},
+		{Op: "delete", ID: "old-id"},
+	}
+
+	if err := client.TransactItems(context.Background(), ops, ""); err != nil {
+		t.Fatalf("TransactItems returned error: %v", err)
+	}
+
+	if captured == nil || len(captured.TransactItems) != 2 {
+		t.Fatalf("Expected 2 transact items, got %+v", captured)
+	}
+	if captured.TransactItems[0].Put == nil {
+		t.Error("Expected first op to be a Put")
+	}
+	if captured.TransactItems[1].Delete == nil {
+		t.Error("Expected second op to be a Delete")
+	}
+}
+
+func TestTransactItemsScopesUpdateDeleteAndConditionCheckToOwner(t *testing.T) {
+	var captured *dynamodb.TransactWriteItemsInput
+	api := &mockDynamoDBAPI{
+		transactWriteItemsFn: func(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+			captured = params
+			return &dynamodb.TransactWriteItemsOutput{}, nil
+		},
+	}
+	client := NewDynamoDBClientWithAPI(api, "test-table")
+
+	name := "Updated"
+	ops := []models.TransactOp{
+		{Op: "update", ID: "id-a", Update: &models.ItemUpdate{Name: &name}},
+		{Op: "delete", ID: "id-b"},
+		{Op: "condition-check", ID: "id-c"},
+	}
+
+	if err := client.TransactItems(context.Background(), ops, "owner-1"); err != nil {
+		t.Fatalf("TransactItems returned error: %v", err)
+	}
+
+	if captured.TransactItems[0].Update.ConditionExpression == nil || *captured.TransactItems[0].Update.ConditionExpression != "owner_id = :owner_id" {
+		t.Errorf("Expected update to be conditioned on owner_id, got %+v", captured.TransactItems[0].Update)
+	}
+	if captured.TransactItems[1].Delete.ConditionExpression == nil || *captured.TransactItems[1].Delete.ConditionExpression != "attribute_exists(id) AND owner_id = :owner_id" {
+		t.Errorf("Expected delete to be conditioned on owner_id, got %+v", captured.TransactItems[1].Delete)
+	}
+	if captured.TransactItems[2].ConditionCheck.ConditionExpression == nil || *captured.TransactItems[2].ConditionCheck.ConditionExpression != "attribute_exists(id) AND owner_id = :owner_id" {
+		t.Errorf("Expected condition-check to be conditioned on owner_id, got %+v", captured.TransactItems[2].ConditionCheck)
+	}
+}
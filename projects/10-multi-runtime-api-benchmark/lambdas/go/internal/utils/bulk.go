@@ -0,0 +1,301 @@
+package utils
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/uuid"
+	"github.com/vibtellect/benchmark-go-lambda/internal/models"
+)
+
+// BulkCreateItems creates every item in items, reporting per-item
+// success/failure keyed by the caller's client_ref so a failed create (which
+// never gets a server-assigned ID) stays identifiable. When atomic is false,
+// items go through the same chunked, retrying BatchWriteItem path as
+// BatchCreateItems, which stops at the first chunk that fails: every item of
+// that chunk and of any later (never sent) chunk is reported failed with that
+// error, even though DynamoDB may already have written part of the failed
+// chunk before its retries ran out.
+// When atomic is true, every item is created in a single TransactWriteItems
+// call that succeeds or fails together.
+func (db *DynamoDBClient) BulkCreateItems(ctx context.Context, items []models.BulkCreateItem, ownerID string, atomic bool) []models.BulkResult {
+	if atomic {
+		return db.bulkCreateAtomic(ctx, items, ownerID)
+	}
+
+	itemsData := make([]models.ItemCreate, len(items))
+	for i, it := range items {
+		itemsData[i] = it.ItemCreate
+	}
+
+	created, err := db.BatchCreateItems(ctx, itemsData, ownerID)
+
+	results := make([]models.BulkResult, len(items))
+	for i, it := range items {
+		if i < len(created) {
+			item := created[i]
+			results[i] = models.BulkResult{ClientRef: it.ClientRef, Success: true, Item: &item}
+			continue
+		}
+		results[i] = models.BulkResult{ClientRef: it.ClientRef, Success: false, Error: errMessage(err)}
+	}
+	return results
+}
+
+// bulkCreateAtomic creates every item in a single DynamoDB transaction, the
+// same way CreateItem builds a single item, so atomic creates get the same
+// OwnerID/Category/Labels/Archived/Version fields a non-atomic create would.
+func (db *DynamoDBClient) bulkCreateAtomic(ctx context.Context, items []models.BulkCreateItem, ownerID string) []models.BulkResult {
+	ctx, span := Tracer.Start(ctx, "DynamoDB.BulkCreateItems.atomic")
+	defer span.End()
+
+	currentTime := models.CurrentTimestamp()
+	created := make([]models.Item, len(items))
+	transactItems := make([]types.TransactWriteItem, len(items))
+
+	for i, it := range items {
+		item := models.Item{
+			ID:          uuid.New().String(),
+			Name:        it.Name,
+			Description: it.Description,
+			Price:       it.Price,
+			Category:    it.Category,
+			Labels:      it.Labels,
+			Archived:    it.Archived,
+			OwnerID:     ownerID,
+			CreatedAt:   currentTime,
+			UpdatedAt:   currentTime,
+			Version:     1,
+			ItemType:    itemTypePartition,
+		}
+		created[i] = item
+
+		av, err := marshalMap(item)
+		if err != nil {
+			return allFailed(items, err)
+		}
+		transactItems[i] = types.TransactWriteItem{Put: &types.Put{TableName: aws.String(db.tableName), Item: av}}
+	}
+
+	db.recordBackendCall()
+	_, err := db.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: transactItems})
+	if err != nil {
+		log.Printf("Error committing atomic bulk create of %d items: %v", len(items), err)
+		return allFailed(items, err)
+	}
+
+	log.Printf("Atomic bulk create committed %d items", len(items))
+	results := make([]models.BulkResult, len(items))
+	for i, it := range items {
+		item := created[i]
+		results[i] = models.BulkResult{ClientRef: it.ClientRef, Success: true, Item: &item}
+	}
+	return results
+}
+
+// BulkUpdateItems updates every item in items, reporting per-item
+// success/failure keyed by the caller's client_ref. DynamoDB's
+// BatchWriteItem has no update action, so the non-atomic path (atomic
+// false) fans out one UpdateItem call per item concurrently instead -
+// exactly the kind of workload this endpoint exists to benchmark. When
+// atomic is true, every update runs in a single TransactWriteItems call
+// that succeeds or fails together, conditioned on owner_id = ownerID the
+// same way TransactItems' "update" op and bulkDeleteAtomic are.
+func (db *DynamoDBClient) BulkUpdateItems(ctx context.Context, items []models.BulkUpdateItem, ownerID string, atomic bool) []models.BulkResult {
+	if atomic {
+		return db.bulkUpdateAtomic(ctx, items, ownerID)
+	}
+
+	results := make([]models.BulkResult, len(items))
+	var wg sync.WaitGroup
+	wg.Add(len(items))
+
+	for i, it := range items {
+		go func(i int, it models.BulkUpdateItem) {
+			defer wg.Done()
+
+			item, err := db.UpdateItem(ctx, it.ID, it.ItemUpdate, it.ItemUpdate.Version, ownerID)
+			switch {
+			case err != nil:
+				results[i] = models.BulkResult{ClientRef: it.ClientRef, Success: false, Error: errMessage(err)}
+			case item == nil:
+				results[i] = models.BulkResult{ClientRef: it.ClientRef, Success: false, Error: "item not found"}
+			default:
+				results[i] = models.BulkResult{ClientRef: it.ClientRef, Success: true, Item: item}
+			}
+		}(i, it)
+	}
+
+	wg.Wait()
+	return results
+}
+
+func (db *DynamoDBClient) bulkUpdateAtomic(ctx context.Context, items []models.BulkUpdateItem, ownerID string) []models.BulkResult {
+	ctx, span := Tracer.Start(ctx, "DynamoDB.BulkUpdateItems.atomic")
+	defer span.End()
+
+	transactItems := make([]types.TransactWriteItem, len(items))
+	for i, it := range items {
+		updateExpression, expressionNames, expressionValues := buildUpdateExpression(it.ItemUpdate)
+		update := &types.Update{
+			TableName:                 aws.String(db.tableName),
+			Key:                       map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: it.ID}},
+			UpdateExpression:          aws.String(updateExpression),
+			ExpressionAttributeValues: expressionValues,
+		}
+		if ownerID != "" {
+			update.ConditionExpression = aws.String("owner_id = :owner_id")
+			update.ExpressionAttributeValues[":owner_id"] = &types.AttributeValueMemberS{Value: ownerID}
+		}
+		if len(expressionNames) > 0 {
+			update.ExpressionAttributeNames = expressionNames
+		}
+		transactItems[i] = types.TransactWriteItem{Update: update}
+	}
+
+	db.recordBackendCall()
+	_, err := db.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: transactItems})
+	if err != nil {
+		log.Printf("Error committing atomic bulk update of %d items: %v", len(items), err)
+		return allFailedUpdate(items, err)
+	}
+
+	log.Printf("Atomic bulk update committed %d items", len(items))
+	results := make([]models.BulkResult, len(items))
+	for i, it := range items {
+		results[i] = models.BulkResult{ClientRef: it.ClientRef, Success: true}
+	}
+	return results
+}
+
+// BulkDeleteItems deletes every item in items, reporting per-item
+// success/failure keyed by the caller's client_ref. Both paths are scoped to
+// ownerID when it's non-empty: an item owned by someone else is reported
+// "item not found", the same not-found-not-403 treatment GetItem/DeleteItem
+// give a mismatched owner. When atomic is false, ids are first filtered down
+// to the ones ownerID actually owns via BatchGetItems (skipped entirely when
+// ownerID is empty), then deleted through the same chunked, retrying
+// BatchWriteItem path as BatchDeleteItems, so every item in a failed chunk
+// is reported failed together - BatchWriteItem has no per-item failure
+// signal short of that. When atomic is true, every delete runs in a single
+// TransactWriteItems call, conditioned on attribute_exists(id) (and
+// owner_id = ownerID when ownerID is set) so a missing or not-owned ID fails
+// the whole transaction instead of silently no-op'ing or deleting across
+// owners.
+func (db *DynamoDBClient) BulkDeleteItems(ctx context.Context, items []models.BulkDeleteItem, ownerID string, atomic bool) []models.BulkResult {
+	if atomic {
+		return db.bulkDeleteAtomic(ctx, items, ownerID)
+	}
+
+	ids := make([]string, len(items))
+	for i, it := range items {
+		ids[i] = it.ID
+	}
+
+	deleteIDs := ids
+	notFound := make(map[string]bool)
+	if ownerID != "" {
+		owned, missing, err := db.BatchGetItems(ctx, ids, ownerID)
+		if err != nil {
+			return allFailedDelete(items, err)
+		}
+
+		deleteIDs = make([]string, len(owned))
+		for i, item := range owned {
+			deleteIDs[i] = item.ID
+		}
+		for _, id := range missing {
+			notFound[id] = true
+		}
+	}
+
+	deleteErr := db.BatchDeleteItems(ctx, deleteIDs)
+
+	results := make([]models.BulkResult, len(items))
+	for i, it := range items {
+		switch {
+		case notFound[it.ID]:
+			results[i] = models.BulkResult{ClientRef: it.ClientRef, Success: false, Error: "item not found"}
+		case deleteErr != nil:
+			results[i] = models.BulkResult{ClientRef: it.ClientRef, Success: false, Error: errMessage(deleteErr)}
+		default:
+			results[i] = models.BulkResult{ClientRef: it.ClientRef, Success: true}
+		}
+	}
+	return results
+}
+
+func (db *DynamoDBClient) bulkDeleteAtomic(ctx context.Context, items []models.BulkDeleteItem, ownerID string) []models.BulkResult {
+	ctx, span := Tracer.Start(ctx, "DynamoDB.BulkDeleteItems.atomic")
+	defer span.End()
+
+	transactItems := make([]types.TransactWriteItem, len(items))
+	for i, it := range items {
+		del := &types.Delete{
+			TableName:           aws.String(db.tableName),
+			Key:                 map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: it.ID}},
+			ConditionExpression: aws.String("attribute_exists(id)"),
+		}
+		if ownerID != "" {
+			del.ConditionExpression = aws.String("attribute_exists(id) AND owner_id = :owner_id")
+			del.ExpressionAttributeValues = map[string]types.AttributeValue{
+				":owner_id": &types.AttributeValueMemberS{Value: ownerID},
+			}
+		}
+		transactItems[i] = types.TransactWriteItem{Delete: del}
+	}
+
+	db.recordBackendCall()
+	_, err := db.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: transactItems})
+
+	results := make([]models.BulkResult, len(items))
+	for i, it := range items {
+		if err != nil {
+			results[i] = models.BulkResult{ClientRef: it.ClientRef, Success: false, Error: errMessage(err)}
+			continue
+		}
+		results[i] = models.BulkResult{ClientRef: it.ClientRef, Success: true}
+	}
+	if err != nil {
+		log.Printf("Error committing atomic bulk delete of %d items: %v", len(items), err)
+		return results
+	}
+
+	log.Printf("Atomic bulk delete committed %d items", len(items))
+	return results
+}
+
+func errMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+func allFailed(items []models.BulkCreateItem, err error) []models.BulkResult {
+	results := make([]models.BulkResult, len(items))
+	for i, it := range items {
+		results[i] = models.BulkResult{ClientRef: it.ClientRef, Success: false, Error: errMessage(err)}
+	}
+	return results
+}
+
+func allFailedUpdate(items []models.BulkUpdateItem, err error) []models.BulkResult {
+	results := make([]models.BulkResult, len(items))
+	for i, it := range items {
+		results[i] = models.BulkResult{ClientRef: it.ClientRef, Success: false, Error: errMessage(err)}
+	}
+	return results
+}
+
+func allFailedDelete(items []models.BulkDeleteItem, err error) []models.BulkResult {
+	results := make([]models.BulkResult, len(items))
+	for i, it := range items {
+		results[i] = models.BulkResult{ClientRef: it.ClientRef, Success: false, Error: errMessage(err)}
+	}
+	return results
+}
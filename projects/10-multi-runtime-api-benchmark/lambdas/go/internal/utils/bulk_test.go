@@ -0,0 +1,228 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/vibtellect/benchmark-go-lambda/internal/models"
+)
+
+func TestBulkCreateItemsNonAtomicSuccess(t *testing.T) {
+	api := &mockDynamoDBAPI{
+		batchWriteItemFn: func(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+			return &dynamodb.BatchWriteItemOutput{}, nil
+		},
+	}
+	client := NewDynamoDBClientWithAPI(api, "test-table")
+
+	items := []models.BulkCreateItem{
+		{ClientRef: "a", ItemCreate: models.ItemCreate{Name: "A", Price: 1}},
+		{ClientRef: "b", ItemCreate: models.ItemCreate{Name: "B", Price: 2}},
+	}
+
+	results := client.BulkCreateItems(context.Background(), items, "owner-1", false)
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	for i, r := range results {
+		if !r.Success || r.ClientRef != items[i].ClientRef || r.Item == nil {
+			t.Errorf("Expected result %d to succeed for client_ref %q, got %+v", i, items[i].ClientRef, r)
+		}
+	}
+}
+
+func TestBulkCreateItemsAtomicCommitsSingleTransaction(t *testing.T) {
+	var calls int
+	api := &mockDynamoDBAPI{
+		transactWriteItemsFn: func(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+			calls++
+			if len(params.TransactItems) != 2 {
+				t.Errorf("Expected 2 transact items, got %d", len(params.TransactItems))
+			}
+			return &dynamodb.TransactWriteItemsOutput{}, nil
+		},
+	}
+	client := NewDynamoDBClientWithAPI(api, "test-table")
+
+	items := []models.BulkCreateItem{
+		{ClientRef: "a", ItemCreate: models.ItemCreate{Name: "A", Price: 1}},
+		{ClientRef: "b", ItemCreate: models.ItemCreate{Name: "B", Price: 2}},
+	}
+
+	results := client.BulkCreateItems(context.Background(), items, "owner-1", true)
+	if calls != 1 {
+		t.Errorf("Expected a single TransactWriteItems call, got %d", calls)
+	}
+	for _, r := range results {
+		if !r.Success {
+			t.Errorf("Expected atomic create to succeed, got %+v", r)
+		}
+	}
+}
+
+func TestBulkCreateItemsAtomicFailureFailsEveryItem(t *testing.T) {
+	api := &mockDynamoDBAPI{
+		transactWriteItemsFn: func(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+			code := "ConditionalCheckFailed"
+			return nil, &types.TransactionCanceledException{
+				CancellationReasons: []types.CancellationReason{{Code: &code}},
+			}
+		},
+	}
+	client := NewDynamoDBClientWithAPI(api, "test-table")
+
+	items := []models.BulkCreateItem{
+		{ClientRef: "a", ItemCreate: models.ItemCreate{Name: "A", Price: 1}},
+		{ClientRef: "b", ItemCreate: models.ItemCreate{Name: "B", Price: 2}},
+	}
+
+	results := client.BulkCreateItems(context.Background(), items, "owner-1", true)
+	for _, r := range results {
+		if r.Success || r.Error == "" {
+			t.Errorf("Expected atomic create failure to fail every item, got %+v", r)
+		}
+	}
+}
+
+func TestBulkUpdateItemsNonAtomicConcurrent(t *testing.T) {
+	api := &mockDynamoDBAPI{
+		getItemFn: func(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+			av, _ := marshalMap(models.Item{ID: params.Key["id"].(*types.AttributeValueMemberS).Value, Name: "Existing"})
+			return &dynamodb.GetItemOutput{Item: av}, nil
+		},
+		updateItemFn: func(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+			av, _ := marshalMap(models.Item{ID: params.Key["id"].(*types.AttributeValueMemberS).Value, Name: "Updated"})
+			return &dynamodb.UpdateItemOutput{Attributes: av}, nil
+		},
+	}
+	client := NewDynamoDBClientWithAPI(api, "test-table")
+
+	name := "Updated"
+	items := []models.BulkUpdateItem{
+		{ClientRef: "a", ID: "id-a", ItemUpdate: models.ItemUpdate{Name: &name}},
+		{ClientRef: "b", ID: "id-b", ItemUpdate: models.ItemUpdate{Name: &name}},
+	}
+
+	results := client.BulkUpdateItems(context.Background(), items, "", false)
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	for i, r := range results {
+		if !r.Success || r.ClientRef != items[i].ClientRef {
+			t.Errorf("Expected result %d to succeed for client_ref %q, got %+v", i, items[i].ClientRef, r)
+		}
+	}
+}
+
+func TestBulkUpdateItemsAtomicScopesToOwner(t *testing.T) {
+	api := &mockDynamoDBAPI{
+		transactWriteItemsFn: func(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+			for _, ti := range params.TransactItems {
+				if ti.Update == nil || ti.Update.ConditionExpression == nil || *ti.Update.ConditionExpression != "owner_id = :owner_id" {
+					t.Errorf("Expected every update to condition on owner_id, got %+v", ti)
+				}
+				if _, ok := ti.Update.ExpressionAttributeValues[":owner_id"]; !ok {
+					t.Errorf("Expected :owner_id in ExpressionAttributeValues, got %+v", ti.Update.ExpressionAttributeValues)
+				}
+			}
+			return &dynamodb.TransactWriteItemsOutput{}, nil
+		},
+	}
+	client := NewDynamoDBClientWithAPI(api, "test-table")
+
+	name := "Updated"
+	items := []models.BulkUpdateItem{{ClientRef: "a", ID: "id-a", ItemUpdate: models.ItemUpdate{Name: &name}}}
+
+	results := client.BulkUpdateItems(context.Background(), items, "owner-1", true)
+	if !results[0].Success {
+		t.Errorf("Expected atomic update to succeed, got %+v", results[0])
+	}
+}
+
+func TestBulkDeleteItemsNonAtomicSuccess(t *testing.T) {
+	api := &mockDynamoDBAPI{
+		batchWriteItemFn: func(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+			return &dynamodb.BatchWriteItemOutput{}, nil
+		},
+	}
+	client := NewDynamoDBClientWithAPI(api, "test-table")
+
+	items := []models.BulkDeleteItem{{ClientRef: "a", ID: "id-a"}, {ClientRef: "b", ID: "id-b"}}
+
+	results := client.BulkDeleteItems(context.Background(), items, "", false)
+	for i, r := range results {
+		if !r.Success || r.ClientRef != items[i].ClientRef {
+			t.Errorf("Expected result %d to succeed, got %+v", i, r)
+		}
+	}
+}
+
+func TestBulkDeleteItemsNonAtomicExcludesOtherOwners(t *testing.T) {
+	api := &mockDynamoDBAPI{
+		batchGetItemFn: func(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error) {
+			mine, _ := marshalMap(models.Item{ID: "mine", OwnerID: "owner-1"})
+			theirs, _ := marshalMap(models.Item{ID: "theirs", OwnerID: "owner-2"})
+			return &dynamodb.BatchGetItemOutput{
+				Responses: map[string][]map[string]types.AttributeValue{"test-table": {mine, theirs}},
+			}, nil
+		},
+		batchWriteItemFn: func(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+			return &dynamodb.BatchWriteItemOutput{}, nil
+		},
+	}
+	client := NewDynamoDBClientWithAPI(api, "test-table")
+
+	items := []models.BulkDeleteItem{{ClientRef: "mine", ID: "mine"}, {ClientRef: "theirs", ID: "theirs"}}
+
+	results := client.BulkDeleteItems(context.Background(), items, "owner-1", false)
+	if !results[0].Success {
+		t.Errorf("Expected owned item to delete successfully, got %+v", results[0])
+	}
+	if results[1].Success || results[1].Error != "item not found" {
+		t.Errorf("Expected another owner's item to be reported not found instead of deleted, got %+v", results[1])
+	}
+}
+
+func TestBulkDeleteItemsAtomicUsesConditionExists(t *testing.T) {
+	api := &mockDynamoDBAPI{
+		transactWriteItemsFn: func(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+			for _, ti := range params.TransactItems {
+				if ti.Delete == nil || ti.Delete.ConditionExpression == nil {
+					t.Errorf("Expected every delete to have a ConditionExpression, got %+v", ti)
+				}
+			}
+			return &dynamodb.TransactWriteItemsOutput{}, nil
+		},
+	}
+	client := NewDynamoDBClientWithAPI(api, "test-table")
+
+	items := []models.BulkDeleteItem{{ClientRef: "a", ID: "id-a"}}
+
+	results := client.BulkDeleteItems(context.Background(), items, "", true)
+	if !results[0].Success {
+		t.Errorf("Expected atomic delete to succeed, got %+v", results[0])
+	}
+}
+
+func TestBulkDeleteItemsAtomicScopesToOwner(t *testing.T) {
+	api := &mockDynamoDBAPI{
+		transactWriteItemsFn: func(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+			for _, ti := range params.TransactItems {
+				if ti.Delete == nil || len(ti.Delete.ExpressionAttributeValues) == 0 {
+					t.Errorf("Expected every delete to condition on owner_id, got %+v", ti)
+				}
+			}
+			return &dynamodb.TransactWriteItemsOutput{}, nil
+		},
+	}
+	client := NewDynamoDBClientWithAPI(api, "test-table")
+
+	items := []models.BulkDeleteItem{{ClientRef: "a", ID: "id-a"}}
+
+	results := client.BulkDeleteItems(context.Background(), items, "owner-1", true)
+	if !results[0].Success {
+		t.Errorf("Expected atomic delete to succeed, got %+v", results[0])
+	}
+}
@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// EncodeCursor turns a DynamoDB LastEvaluatedKey into an opaque, URL-safe
+// pagination token so clients never see raw AttributeValue maps. Returns ""
+// when there is no further page.
+func EncodeCursor(key map[string]types.AttributeValue) (string, error) {
+	if len(key) == 0 {
+		return "", nil
+	}
+
+	generic := make(map[string]interface{}, len(key))
+	if err := attributevalue.UnmarshalMap(key, &generic); err != nil {
+		return "", fmt.Errorf("encoding cursor: %w", err)
+	}
+
+	data, err := json.Marshal(generic)
+	if err != nil {
+		return "", fmt.Errorf("encoding cursor: %w", err)
+	}
+
+	return base64.URLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCursor reverses EncodeCursor, validating that the token is
+// well-formed before handing it back to DynamoDB as an ExclusiveStartKey.
+func DecodeCursor(cursor string) (map[string]types.AttributeValue, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	data, err := base64.URLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	var generic map[string]interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	key, err := attributevalue.MarshalMap(generic)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	return key, nil
+}
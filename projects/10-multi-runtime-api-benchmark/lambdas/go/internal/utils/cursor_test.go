@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func TestEncodeDecodeCursorRoundTrip(t *testing.T) {
+	key := map[string]types.AttributeValue{
+		"id": &types.AttributeValueMemberS{Value: "item-123"},
+	}
+
+	cursor, err := EncodeCursor(key)
+	if err != nil {
+		t.Fatalf("EncodeCursor returned error: %v", err)
+	}
+	if cursor == "" {
+		t.Fatal("Expected non-empty cursor")
+	}
+
+	decoded, err := DecodeCursor(cursor)
+	if err != nil {
+		t.Fatalf("DecodeCursor returned error: %v", err)
+	}
+
+	s, ok := decoded["id"].(*types.AttributeValueMemberS)
+	if !ok || s.Value != "item-123" {
+		t.Errorf("Expected decoded id 'item-123', got %+v", decoded["id"])
+	}
+}
+
+func TestEncodeCursorEmptyKey(t *testing.T) {
+	cursor, err := EncodeCursor(nil)
+	if err != nil {
+		t.Fatalf("EncodeCursor returned error: %v", err)
+	}
+	if cursor != "" {
+		t.Errorf("Expected empty cursor for nil key, got %q", cursor)
+	}
+}
+
+func TestDecodeCursorEmptyString(t *testing.T) {
+	decoded, err := DecodeCursor("")
+	if err != nil {
+		t.Fatalf("DecodeCursor returned error: %v", err)
+	}
+	if decoded != nil {
+		t.Errorf("Expected nil key for empty cursor, got %+v", decoded)
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	if _, err := DecodeCursor("not-valid-base64!!!"); err == nil {
+		t.Error("Expected error for invalid cursor")
+	}
+}
@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/aws/aws-dax-go-v2/dax"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+)
+
+// daxAPI adapts *dax.Dax (which implements the DynamoDB v2 client surface)
+// to DynamoDBAPI. Query is the one operation the DAX client doesn't proxy
+// directly to the cluster's own method set, so it's wired through here too.
+type daxAPI struct {
+	client *dax.Dax
+}
+
+func (d *daxAPI) PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	return d.client.PutItem(ctx, params, optFns...)
+}
+
+func (d *daxAPI) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	return d.client.GetItem(ctx, params, optFns...)
+}
+
+func (d *daxAPI) UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	return d.client.UpdateItem(ctx, params, optFns...)
+}
+
+func (d *daxAPI) DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	return d.client.DeleteItem(ctx, params, optFns...)
+}
+
+func (d *daxAPI) Scan(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+	return d.client.Scan(ctx, params, optFns...)
+}
+
+func (d *daxAPI) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	return d.client.Query(ctx, params, optFns...)
+}
+
+func (d *daxAPI) BatchWriteItem(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+	return d.client.BatchWriteItem(ctx, params, optFns...)
+}
+
+func (d *daxAPI) BatchGetItem(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error) {
+	return d.client.BatchGetItem(ctx, params, optFns...)
+}
+
+func (d *daxAPI) TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	return d.client.TransactWriteItems(ctx, params, optFns...)
+}
+
+// backendFromEnv selects the DynamoDBAPI implementation NewDynamoDBClient
+// wires up: a DAX cluster when DAX_ENDPOINT is set, otherwise the plain
+// DynamoDB client passed in as fallback.
+func backendFromEnv(fallback DynamoDBAPI) (api DynamoDBAPI, backend string) {
+	endpoint := os.Getenv("DAX_ENDPOINT")
+	if endpoint == "" {
+		return fallback, "dynamodb"
+	}
+
+	cfg := dax.DefaultConfig()
+	cfg.HostPorts = []string{endpoint}
+	cfg.Region = os.Getenv("AWS_REGION")
+
+	client, err := dax.New(cfg)
+	if err != nil {
+		log.Printf("Error creating DAX client for %s, falling back to DynamoDB: %v", endpoint, err)
+		return fallback, "dynamodb"
+	}
+
+	log.Printf("DAX client initialized for endpoint: %s", endpoint)
+	return &daxAPI{client: client}, "dax"
+}
@@ -1,44 +1,238 @@
 package utils
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"log/slog"
 	"os"
-
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/dynamodb"
-	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-xray-sdk-go/strategy/ctxmissing"
+	"github.com/aws/aws-xray-sdk-go/xray"
 	"github.com/google/uuid"
 	"github.com/vibtellect/benchmark-go-lambda/internal/models"
 )
 
+// ErrVersionMismatch is returned by UpdateItem and DeleteItem when the
+// caller's expected version no longer matches the stored item, i.e.
+// DynamoDB reported a ConditionalCheckFailedException.
+var ErrVersionMismatch = errors.New("item version mismatch")
+
+// isVersionMismatch reports whether err is the ConditionalCheckFailedException
+// DynamoDB returns when a ConditionExpression's version check fails.
+func isVersionMismatch(err error) bool {
+	var condErr *types.ConditionalCheckFailedException
+	return errors.As(err, &condErr)
+}
+
+// DynamoDBAPI is the subset of the aws-sdk-go-v2 DynamoDB client used by
+// DynamoDBClient. It lets handlers substitute a mock in tests instead of
+// depending on a live AWS session.
+type DynamoDBAPI interface {
+	PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error)
+	GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)
+	UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error)
+	DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error)
+	Scan(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error)
+	Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)
+	BatchWriteItem(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error)
+	BatchGetItem(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error)
+	TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error)
+}
+
+// attributevalue (de)serializes with the "attributevalue" struct tag rather
+// than the package default, so models stay free of SDK-version-specific tag
+// names in their primary `json` tag set.
+var (
+	avEncoder = attributevalue.NewEncoder(func(o *attributevalue.EncoderOptions) {
+		o.TagKey = "attributevalue"
+	})
+	avDecoder = attributevalue.NewDecoder(func(o *attributevalue.DecoderOptions) {
+		o.TagKey = "attributevalue"
+	})
+)
+
+func marshalMap(in interface{}) (map[string]types.AttributeValue, error) {
+	av, err := avEncoder.Encode(in)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := av.(*types.AttributeValueMemberM)
+	if !ok {
+		return nil, fmt.Errorf("expected map attribute value, got %T", av)
+	}
+	return m.Value, nil
+}
+
+func unmarshalMap(m map[string]types.AttributeValue, out interface{}) error {
+	return avDecoder.Decode(&types.AttributeValueMemberM{Value: m}, out)
+}
+
 // DynamoDBClient handles DynamoDB operations
 type DynamoDBClient struct {
-	client    *dynamodb.DynamoDB
+	client    DynamoDBAPI
 	tableName string
+	// Backend reports which DynamoDBAPI implementation is in use ("dynamodb"
+	// or "dax"), surfaced via the /health sub-check and cache-path metrics.
+	Backend string
 }
 
-// NewDynamoDBClient creates a new DynamoDB client
+// NewDynamoDBClient creates a new DynamoDB client. When DAX_ENDPOINT is set,
+// reads and writes are routed through a DAX cluster instead of talking to
+// DynamoDB directly; see backendFromEnv.
 func NewDynamoDBClient() *DynamoDBClient {
-	sess := session.Must(session.NewSession())
-	client := dynamodb.New(sess)
+	cfg, err := config.LoadDefaultConfig(context.Background())
+	if err != nil {
+		log.Fatalf("Unable to load AWS config: %v", err)
+	}
+
+	// Wrap every DynamoDB call in its own X-Ray subsegment, so traces show
+	// DB time as distinct from the handler time tracingMiddleware records.
+	// Lambda only puts a segment in context when active tracing is enabled
+	// on the function; log-and-skip rather than the SDK's default panic
+	// when a call happens outside of one (local runs, tracing disabled).
+	xray.Configure(xray.Config{ContextMissingStrategy: ctxmissing.NewDefaultContextMissingStrategy()})
+	xray.AWSV2Instrumentor(&cfg.APIOptions)
 
 	tableName := os.Getenv("TABLE_NAME")
 	if tableName == "" {
 		tableName = "dev-benchmark-items"
 	}
 
-	log.Printf("DynamoDB client initialized for table: %s", tableName)
+	api, backend := backendFromEnv(dynamodb.NewFromConfig(cfg))
+
+	log.Printf("DynamoDB client initialized for table: %s (backend: %s)", tableName, backend)
 
 	return &DynamoDBClient{
-		client:    client,
+		client:    api,
 		tableName: tableName,
+		Backend:   backend,
 	}
 }
 
-// CreateItem creates a new item in DynamoDB
-func (db *DynamoDBClient) CreateItem(itemData models.ItemCreate) (*models.Item, error) {
+// NewDynamoDBClientWithAPI creates a DynamoDBClient backed by an arbitrary
+// DynamoDBAPI implementation, for use in tests. Backend is always reported as
+// "dynamodb", whatever api is.
+func NewDynamoDBClientWithAPI(api DynamoDBAPI, tableName string) *DynamoDBClient {
+	return &DynamoDBClient{
+		client:    api,
+		tableName: tableName,
+		Backend:   "dynamodb",
+	}
+}
+
+func (db *DynamoDBClient) recordBackendCall() {
+	RecordBackendCall(db.Backend)
+}
+
+// consumedCapacity extracts the CapacityUnits DynamoDB reported for a call,
+// for the structured logs emitted by logDynamoDBCall. Returns 0 when the
+// backend didn't return consumed-capacity data (e.g. a DAX cache hit).
+func consumedCapacity(result interface{}) float64 {
+	var cc *types.ConsumedCapacity
+
+	switch r := result.(type) {
+	case *dynamodb.PutItemOutput:
+		if r != nil {
+			cc = r.ConsumedCapacity
+		}
+	case *dynamodb.GetItemOutput:
+		if r != nil {
+			cc = r.ConsumedCapacity
+		}
+	case *dynamodb.UpdateItemOutput:
+		if r != nil {
+			cc = r.ConsumedCapacity
+		}
+	case *dynamodb.DeleteItemOutput:
+		if r != nil {
+			cc = r.ConsumedCapacity
+		}
+	case *dynamodb.ScanOutput:
+		if r != nil {
+			cc = r.ConsumedCapacity
+		}
+	case *dynamodb.QueryOutput:
+		if r != nil {
+			cc = r.ConsumedCapacity
+		}
+	}
+
+	if cc == nil || cc.CapacityUnits == nil {
+		return 0
+	}
+	return *cc.CapacityUnits
+}
+
+// buildUpdateExpression builds the SET clause, names, and values shared by
+// UpdateItem and the "update" op in TransactItems so both stay in sync. Every
+// update bumps version by one, which UpdateItem also uses as the optimistic
+// concurrency token for the next caller.
+func buildUpdateExpression(itemData models.ItemUpdate) (string, map[string]string, map[string]types.AttributeValue) {
+	// version is a DynamoDB reserved word, same as name, so it needs the
+	// same #alias treatment as #n below.
+	updateExpression := "SET updated_at = :updated_at, #v = if_not_exists(#v, :zero) + :one"
+	expressionValues := map[string]types.AttributeValue{
+		":updated_at": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", models.CurrentTimestamp())},
+		":zero":       &types.AttributeValueMemberN{Value: "0"},
+		":one":        &types.AttributeValueMemberN{Value: "1"},
+	}
+	expressionNames := map[string]string{"#v": "version"}
+
+	if itemData.Name != nil {
+		updateExpression += ", #n = :name"
+		expressionNames["#n"] = "name"
+		expressionValues[":name"] = &types.AttributeValueMemberS{Value: *itemData.Name}
+	}
+
+	if itemData.Description != nil {
+		updateExpression += ", description = :description"
+		expressionValues[":description"] = &types.AttributeValueMemberS{Value: *itemData.Description}
+	}
+
+	if itemData.Price != nil {
+		updateExpression += ", price = :price"
+		expressionValues[":price"] = &types.AttributeValueMemberN{Value: strconv.FormatFloat(*itemData.Price, 'f', -1, 64)}
+	}
+
+	if itemData.Category != nil {
+		updateExpression += ", category = :category"
+		expressionValues[":category"] = &types.AttributeValueMemberS{Value: *itemData.Category}
+	}
+
+	if itemData.Labels != nil {
+		updateExpression += ", labels = :labels"
+		labelValues := make([]types.AttributeValue, len(itemData.Labels))
+		for i, label := range itemData.Labels {
+			labelValues[i] = &types.AttributeValueMemberS{Value: label}
+		}
+		expressionValues[":labels"] = &types.AttributeValueMemberL{Value: labelValues}
+	}
+
+	if itemData.Archived != nil {
+		updateExpression += ", archived = :archived"
+		expressionValues[":archived"] = &types.AttributeValueMemberBOOL{Value: *itemData.Archived}
+	}
+
+	return updateExpression, expressionNames, expressionValues
+}
+
+// CreateItem creates a new item in DynamoDB, owned by ownerID.
+func (db *DynamoDBClient) CreateItem(ctx context.Context, itemData models.ItemCreate, ownerID string) (*models.Item, error) {
+	ctx, span := Tracer.Start(ctx, "DynamoDB.CreateItem")
+	defer span.End()
+	start := time.Now()
+
 	itemID := uuid.New().String()
 	currentTime := models.CurrentTimestamp()
 
@@ -47,68 +241,91 @@ func (db *DynamoDBClient) CreateItem(itemData models.ItemCreate) (*models.Item,
 		Name:        itemData.Name,
 		Description: itemData.Description,
 		Price:       itemData.Price,
+		Category:    itemData.Category,
+		Labels:      itemData.Labels,
+		Archived:    itemData.Archived,
+		OwnerID:     ownerID,
 		CreatedAt:   currentTime,
 		UpdatedAt:   currentTime,
+		Version:     1,
+		ItemType:    itemTypePartition,
 	}
 
-	av, err := dynamodbattribute.MarshalMap(item)
+	av, err := marshalMap(item)
 	if err != nil {
-		log.Printf("Error marshaling item: %v", err)
+		logDynamoDBCall(ctx, "CreateItem", itemID, start, 0, err)
 		return nil, err
 	}
 
 	input := &dynamodb.PutItemInput{
-		TableName: aws.String(db.tableName),
-		Item:      av,
+		TableName:              aws.String(db.tableName),
+		Item:                   av,
+		ReturnConsumedCapacity: types.ReturnConsumedCapacityTotal,
 	}
 
-	_, err = db.client.PutItem(input)
+	db.recordBackendCall()
+	result, err := db.client.PutItem(ctx, input)
+	logDynamoDBCall(ctx, "CreateItem", itemID, start, consumedCapacity(result), err)
 	if err != nil {
-		log.Printf("Error creating item: %v", err)
 		return nil, err
 	}
 
-	log.Printf("Created item: %s", itemID)
 	return &item, nil
 }
 
-// GetItem retrieves an item by ID
-func (db *DynamoDBClient) GetItem(itemID string) (*models.Item, error) {
+// GetItem retrieves an item by ID. When ownerID is non-empty, an item
+// belonging to a different owner is treated the same as a missing one (nil,
+// nil) so callers can't distinguish "not found" from "not yours".
+func (db *DynamoDBClient) GetItem(ctx context.Context, itemID string, ownerID string) (*models.Item, error) {
+	ctx, span := Tracer.Start(ctx, "DynamoDB.GetItem")
+	defer span.End()
+	start := time.Now()
+
 	input := &dynamodb.GetItemInput{
 		TableName: aws.String(db.tableName),
-		Key: map[string]*dynamodb.AttributeValue{
-			"id": {
-				S: aws.String(itemID),
-			},
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: itemID},
 		},
+		ReturnConsumedCapacity: types.ReturnConsumedCapacityTotal,
 	}
 
-	result, err := db.client.GetItem(input)
+	db.recordBackendCall()
+	result, err := db.client.GetItem(ctx, input)
 	if err != nil {
-		log.Printf("Error getting item %s: %v", itemID, err)
+		logDynamoDBCall(ctx, "GetItem", itemID, start, 0, err)
 		return nil, err
 	}
 
 	if result.Item == nil {
-		log.Printf("Item not found: %s", itemID)
+		logDynamoDBCall(ctx, "GetItem", itemID, start, consumedCapacity(result), nil)
 		return nil, nil
 	}
 
 	var item models.Item
-	err = dynamodbattribute.UnmarshalMap(result.Item, &item)
-	if err != nil {
-		log.Printf("Error unmarshaling item: %v", err)
+	if err := unmarshalMap(result.Item, &item); err != nil {
+		logDynamoDBCall(ctx, "GetItem", itemID, start, 0, err)
 		return nil, err
 	}
 
-	log.Printf("Retrieved item: %s", itemID)
+	logDynamoDBCall(ctx, "GetItem", itemID, start, consumedCapacity(result), nil)
+	if ownerID != "" && item.OwnerID != ownerID {
+		return nil, nil
+	}
 	return &item, nil
 }
 
-// UpdateItem updates an existing item
-func (db *DynamoDBClient) UpdateItem(itemID string, itemData models.ItemUpdate) (*models.Item, error) {
-	// First check if item exists
-	existingItem, err := db.GetItem(itemID)
+// UpdateItem updates an existing item. When expectedVersion is non-nil, the
+// update is conditioned on the stored item's version still matching it and
+// ErrVersionMismatch is returned if another writer updated it first. When
+// ownerID is non-empty, an item owned by someone else is reported as not
+// found, same as GetItem.
+func (db *DynamoDBClient) UpdateItem(ctx context.Context, itemID string, itemData models.ItemUpdate, expectedVersion *int64, ownerID string) (*models.Item, error) {
+	ctx, span := Tracer.Start(ctx, "DynamoDB.UpdateItem")
+	defer span.End()
+	start := time.Now()
+
+	// First check if item exists (and, if ownerID is set, that it's theirs)
+	existingItem, err := db.GetItem(ctx, itemID, ownerID)
 	if err != nil {
 		return nil, err
 	}
@@ -116,75 +333,59 @@ func (db *DynamoDBClient) UpdateItem(itemID string, itemData models.ItemUpdate)
 		return nil, nil
 	}
 
-	// Build update expression
-	updateExpression := "SET updated_at = :updated_at"
-	expressionValues := map[string]*dynamodb.AttributeValue{
-		":updated_at": {
-			N: aws.String(fmt.Sprintf("%d", models.CurrentTimestamp())),
-		},
-	}
-
-	if itemData.Name != nil {
-		updateExpression += ", #n = :name"
-		expressionValues[":name"] = &dynamodb.AttributeValue{
-			S: aws.String(*itemData.Name),
-		}
-	}
-
-	if itemData.Description != nil {
-		updateExpression += ", description = :description"
-		expressionValues[":description"] = &dynamodb.AttributeValue{
-			S: aws.String(*itemData.Description),
-		}
-	}
-
-	if itemData.Price != nil {
-		updateExpression += ", price = :price"
-		expressionValues[":price"] = &dynamodb.AttributeValue{
-			N: aws.String(strconv.FormatFloat(*itemData.Price, 'f', -1, 64)),
-		}
-	}
+	updateExpression, expressionNames, expressionValues := buildUpdateExpression(itemData)
 
 	input := &dynamodb.UpdateItemInput{
 		TableName: aws.String(db.tableName),
-		Key: map[string]*dynamodb.AttributeValue{
-			"id": {
-				S: aws.String(itemID),
-			},
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: itemID},
 		},
 		UpdateExpression:          aws.String(updateExpression),
 		ExpressionAttributeValues: expressionValues,
-		ReturnValues:              aws.String("ALL_NEW"),
+		ReturnValues:              types.ReturnValueAllNew,
+		ReturnConsumedCapacity:    types.ReturnConsumedCapacityTotal,
 	}
-
-	// Add expression attribute names if name is being updated
-	if itemData.Name != nil {
-		input.ExpressionAttributeNames = map[string]*string{
-			"#n": aws.String("name"),
-		}
+	if expectedVersion != nil {
+		input.ConditionExpression = aws.String("#v = :expected_version")
+		expressionValues[":expected_version"] = &types.AttributeValueMemberN{Value: strconv.FormatInt(*expectedVersion, 10)}
+	}
+	if len(expressionNames) > 0 {
+		input.ExpressionAttributeNames = expressionNames
 	}
 
-	result, err := db.client.UpdateItem(input)
+	db.recordBackendCall()
+	result, err := db.client.UpdateItem(ctx, input)
 	if err != nil {
-		log.Printf("Error updating item %s: %v", itemID, err)
+		if isVersionMismatch(err) {
+			logDynamoDBCall(ctx, "UpdateItem", itemID, start, 0, ErrVersionMismatch)
+			return nil, ErrVersionMismatch
+		}
+		logDynamoDBCall(ctx, "UpdateItem", itemID, start, 0, err)
 		return nil, err
 	}
 
 	var item models.Item
-	err = dynamodbattribute.UnmarshalMap(result.Attributes, &item)
-	if err != nil {
-		log.Printf("Error unmarshaling updated item: %v", err)
+	if err := unmarshalMap(result.Attributes, &item); err != nil {
+		logDynamoDBCall(ctx, "UpdateItem", itemID, start, 0, err)
 		return nil, err
 	}
 
-	log.Printf("Updated item: %s", itemID)
+	logDynamoDBCall(ctx, "UpdateItem", itemID, start, consumedCapacity(result), nil)
 	return &item, nil
 }
 
-// DeleteItem deletes an item
-func (db *DynamoDBClient) DeleteItem(itemID string) (bool, error) {
-	// Check if item exists first
-	existingItem, err := db.GetItem(itemID)
+// DeleteItem deletes an item. When expectedVersion is non-nil, the delete is
+// conditioned on the stored item's version still matching it and
+// ErrVersionMismatch is returned if another writer updated it first. When
+// ownerID is non-empty, an item owned by someone else is reported as not
+// found, same as GetItem.
+func (db *DynamoDBClient) DeleteItem(ctx context.Context, itemID string, expectedVersion *int64, ownerID string) (bool, error) {
+	ctx, span := Tracer.Start(ctx, "DynamoDB.DeleteItem")
+	defer span.End()
+	start := time.Now()
+
+	// Check if item exists first (and, if ownerID is set, that it's theirs)
+	existingItem, err := db.GetItem(ctx, itemID, ownerID)
 	if err != nil {
 		return false, err
 	}
@@ -194,53 +395,425 @@ func (db *DynamoDBClient) DeleteItem(itemID string) (bool, error) {
 
 	input := &dynamodb.DeleteItemInput{
 		TableName: aws.String(db.tableName),
-		Key: map[string]*dynamodb.AttributeValue{
-			"id": {
-				S: aws.String(itemID),
-			},
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: itemID},
 		},
+		ReturnConsumedCapacity: types.ReturnConsumedCapacityTotal,
+	}
+	if expectedVersion != nil {
+		input.ConditionExpression = aws.String("#v = :expected_version")
+		input.ExpressionAttributeNames = map[string]string{"#v": "version"}
+		input.ExpressionAttributeValues = map[string]types.AttributeValue{
+			":expected_version": &types.AttributeValueMemberN{Value: strconv.FormatInt(*expectedVersion, 10)},
+		}
 	}
 
-	_, err = db.client.DeleteItem(input)
+	db.recordBackendCall()
+	result, err := db.client.DeleteItem(ctx, input)
 	if err != nil {
-		log.Printf("Error deleting item %s: %v", itemID, err)
+		if isVersionMismatch(err) {
+			logDynamoDBCall(ctx, "DeleteItem", itemID, start, 0, ErrVersionMismatch)
+			return false, ErrVersionMismatch
+		}
+		logDynamoDBCall(ctx, "DeleteItem", itemID, start, 0, err)
 		return false, err
 	}
 
-	log.Printf("Deleted item: %s", itemID)
+	logDynamoDBCall(ctx, "DeleteItem", itemID, start, consumedCapacity(result), nil)
 	return true, nil
 }
 
-// ListItems lists all items with optional limit and pagination support
-// Returns items, lastEvaluatedKey for pagination, and error
-func (db *DynamoDBClient) ListItems(limit int64, exclusiveStartKey map[string]*dynamodb.AttributeValue) ([]models.Item, map[string]*dynamodb.AttributeValue, error) {
+// ListItemsInput configures ListItems. Setting IndexName switches from a
+// table Scan to a Query against that GSI, which is required to use
+// NamePrefix (name-index only). Min/MaxPrice narrow the key condition on
+// price-index and are applied as a FilterExpression on every other path
+// (see buildItemFilter); a single Categories value switches to
+// category-index automatically (see buildItemIndexPlan).
+type ListItemsInput struct {
+	Limit             int64
+	ExclusiveStartKey map[string]types.AttributeValue
+	IndexName         string
+	NamePrefix        string
+	MinPrice          *float64
+	MaxPrice          *float64
+
+	// Search, Categories, Labels, and IncludeArchived are applied as a
+	// FilterExpression alongside whatever Scan/Query above already ran, so
+	// they can be combined with any index path. Labels is many-valued with
+	// no matching GSI (DynamoDB GSI keys must be scalar), so it is always
+	// filter-only; a multi-value Categories also falls back to filtering,
+	// since a KeyCondition can only pin down one exact partition/sort value.
+	Search          string
+	Categories      []string
+	Labels          []string
+	IncludeArchived bool
+
+	// OwnerID, when non-empty, restricts results to items owned by that
+	// caller. There is no owner-index GSI (every authenticated caller lists
+	// their own items, not the whole table), so this is always applied as a
+	// filter, same as Labels.
+	OwnerID string
+
+	// SortColumn ("name", "price", "created_at", "updated_at") and
+	// SortOrder ("asc", "desc") re-sort the page ListItems already fetched.
+	// DynamoDB has no ORDER BY over the full result set without scanning
+	// it, so this only orders the items within the current page.
+	SortColumn string
+	SortOrder  string
+
+	// IncludeTotal additionally re-runs the same Scan/Query as a
+	// Select: COUNT pass across every matching page, to report a
+	// table-wide total in the response. It roughly doubles the read cost
+	// of the call, so leave it false for infinite-scroll style pagination
+	// that doesn't need a total.
+	IncludeTotal bool
+}
+
+// itemTypePartition is the constant partition key value every item is
+// written under on the name/price/category GSIs, so those indexes can be
+// queried (not scanned) by sort-key prefix, range, or exact match.
+const itemTypePartition = "ITEM"
+
+// itemQueryPlan is the DynamoDB shape (GSI Query vs table Scan, key
+// condition, filter expression) ListItems and its Select: COUNT companion
+// in countItems share, so "how many items match" and "which items are on
+// this page" never disagree about what counts as a match.
+type itemQueryPlan struct {
+	indexName        string
+	keyCondition     string
+	keyNames         map[string]string
+	keyValues        map[string]types.AttributeValue
+	filterExpression string
+	filterNames      map[string]string
+	filterValues     map[string]types.AttributeValue
+}
+
+// ListItems lists items, either scanning the whole table or, when an index
+// applies, querying a GSI by name prefix, price range, or exact category.
+// Returns items, lastEvaluatedKey for pagination, the total matching count
+// (only populated when input.IncludeTotal is set), and error.
+func (db *DynamoDBClient) ListItems(ctx context.Context, input ListItemsInput) ([]models.Item, map[string]types.AttributeValue, int, error) {
+	ctx, span := Tracer.Start(ctx, "DynamoDB.ListItems")
+	defer span.End()
+	start := time.Now()
+
+	limit := input.Limit
 	if limit <= 0 {
 		limit = 100
 	}
 
-	input := &dynamodb.ScanInput{
-		TableName:         aws.String(db.tableName),
-		Limit:             aws.Int64(limit),
-		ExclusiveStartKey: exclusiveStartKey,
-	}
+	plan := buildItemQueryPlan(input)
 
-	result, err := db.client.Scan(input)
-	if err != nil {
-		log.Printf("Error listing items: %v", err)
-		return nil, nil, err
+	var (
+		items   []map[string]types.AttributeValue
+		lastKey map[string]types.AttributeValue
+		cc      float64
+	)
+
+	db.recordBackendCall()
+	if plan.indexName != "" {
+		result, err := db.queryItems(ctx, plan, input.ExclusiveStartKey, limit, "")
+		if err != nil {
+			logDynamoDBCall(ctx, "ListItems", "", start, 0, err)
+			return nil, nil, 0, err
+		}
+		items, lastKey, cc = result.Items, result.LastEvaluatedKey, consumedCapacity(result)
+	} else {
+		scanInput := &dynamodb.ScanInput{
+			TableName:              aws.String(db.tableName),
+			Limit:                  aws.Int32(int32(limit)),
+			ExclusiveStartKey:      input.ExclusiveStartKey,
+			ReturnConsumedCapacity: types.ReturnConsumedCapacityTotal,
+		}
+		if plan.filterExpression != "" {
+			scanInput.FilterExpression = aws.String(plan.filterExpression)
+			scanInput.ExpressionAttributeValues = plan.filterValues
+			if len(plan.filterNames) > 0 {
+				scanInput.ExpressionAttributeNames = plan.filterNames
+			}
+		}
+		result, err := db.client.Scan(ctx, scanInput)
+		if err != nil {
+			logDynamoDBCall(ctx, "ListItems", "", start, 0, err)
+			return nil, nil, 0, err
+		}
+		items, lastKey, cc = result.Items, result.LastEvaluatedKey, consumedCapacity(result)
 	}
 
-	items := make([]models.Item, 0, len(result.Items))
-	for _, i := range result.Items {
+	parsedItems := make([]models.Item, 0, len(items))
+	for _, i := range items {
 		var item models.Item
-		err = dynamodbattribute.UnmarshalMap(i, &item)
-		if err != nil {
-			log.Printf("Error unmarshaling item: %v", err)
+		if err := unmarshalMap(i, &item); err != nil {
+			Logger.Error("error unmarshaling item in list", slog.String("error", err.Error()))
 			continue
 		}
-		items = append(items, item)
+		parsedItems = append(parsedItems, item)
+	}
+	sortItems(parsedItems, input.SortColumn, input.SortOrder)
+
+	var total int
+	if input.IncludeTotal {
+		var err error
+		total, err = db.countItems(ctx, plan)
+		if err != nil {
+			logDynamoDBCall(ctx, "ListItems", "", start, cc, nil)
+			return nil, nil, 0, fmt.Errorf("counting matching items: %w", err)
+		}
+	}
+
+	logDynamoDBCall(ctx, "ListItems", "", start, cc, nil)
+	return parsedItems, lastKey, total, nil
+}
+
+// buildItemIndexPlan picks the GSI (if any) ListItems queries and the
+// KeyConditionExpression/names/values that go with it.
+func buildItemIndexPlan(input ListItemsInput) (indexName, keyCondition string, keyNames map[string]string, keyValues map[string]types.AttributeValue) {
+	indexName = input.IndexName
+	if indexName == "" && len(input.Categories) == 1 {
+		indexName = "category-index"
+	}
+	if indexName == "" {
+		return "", "", nil, nil
+	}
+
+	keyCondition = "item_type = :item_type"
+	keyNames = map[string]string{}
+	keyValues = map[string]types.AttributeValue{
+		":item_type": &types.AttributeValueMemberS{Value: itemTypePartition},
+	}
+
+	switch indexName {
+	case "name-index":
+		if input.NamePrefix != "" {
+			keyCondition += " AND begins_with(#n, :name_prefix)"
+			keyNames["#n"] = "name"
+			keyValues[":name_prefix"] = &types.AttributeValueMemberS{Value: input.NamePrefix}
+		}
+	case "price-index":
+		switch {
+		case input.MinPrice != nil && input.MaxPrice != nil:
+			keyCondition += " AND price BETWEEN :min_price AND :max_price"
+			keyValues[":min_price"] = &types.AttributeValueMemberN{Value: strconv.FormatFloat(*input.MinPrice, 'f', -1, 64)}
+			keyValues[":max_price"] = &types.AttributeValueMemberN{Value: strconv.FormatFloat(*input.MaxPrice, 'f', -1, 64)}
+		case input.MinPrice != nil:
+			keyCondition += " AND price >= :min_price"
+			keyValues[":min_price"] = &types.AttributeValueMemberN{Value: strconv.FormatFloat(*input.MinPrice, 'f', -1, 64)}
+		case input.MaxPrice != nil:
+			keyCondition += " AND price <= :max_price"
+			keyValues[":max_price"] = &types.AttributeValueMemberN{Value: strconv.FormatFloat(*input.MaxPrice, 'f', -1, 64)}
+		}
+	case "category-index":
+		if len(input.Categories) == 1 {
+			keyCondition += " AND category = :category"
+			keyValues[":category"] = &types.AttributeValueMemberS{Value: input.Categories[0]}
+		}
+	}
+	return indexName, keyCondition, keyNames, keyValues
+}
+
+// buildItemFilter builds the FilterExpression applied on top of whatever
+// Scan/Query indexName already ran, covering Search, any Categories not
+// already pinned down by an exact category-index match, Labels,
+// archived-exclusion, and Min/MaxPrice when price-index didn't already
+// narrow the Query's KeyConditionExpression to them.
+func buildItemFilter(input ListItemsInput, indexName string) (filterExpression string, names map[string]string, values map[string]types.AttributeValue) {
+	names = map[string]string{}
+	values = map[string]types.AttributeValue{}
+	var clauses []string
+
+	if input.Search != "" {
+		names["#n"] = "name"
+		values[":q"] = &types.AttributeValueMemberS{Value: input.Search}
+		clauses = append(clauses, "(contains(#n, :q) OR contains(description, :q))")
 	}
 
-	log.Printf("Listed %d items (hasMore: %v)", len(items), len(result.LastEvaluatedKey) > 0)
-	return items, result.LastEvaluatedKey, nil
+	categoryFilterNeeded := len(input.Categories) > 1 || (len(input.Categories) == 1 && indexName != "category-index")
+	if categoryFilterNeeded {
+		categoryConds := make([]string, len(input.Categories))
+		for i, category := range input.Categories {
+			key := fmt.Sprintf(":cat%d", i)
+			values[key] = &types.AttributeValueMemberS{Value: category}
+			categoryConds[i] = "category = " + key
+		}
+		clauses = append(clauses, "("+strings.Join(categoryConds, " OR ")+")")
+	}
+
+	if indexName != "price-index" {
+		switch {
+		case input.MinPrice != nil && input.MaxPrice != nil:
+			values[":min_price"] = &types.AttributeValueMemberN{Value: strconv.FormatFloat(*input.MinPrice, 'f', -1, 64)}
+			values[":max_price"] = &types.AttributeValueMemberN{Value: strconv.FormatFloat(*input.MaxPrice, 'f', -1, 64)}
+			clauses = append(clauses, "price BETWEEN :min_price AND :max_price")
+		case input.MinPrice != nil:
+			values[":min_price"] = &types.AttributeValueMemberN{Value: strconv.FormatFloat(*input.MinPrice, 'f', -1, 64)}
+			clauses = append(clauses, "price >= :min_price")
+		case input.MaxPrice != nil:
+			values[":max_price"] = &types.AttributeValueMemberN{Value: strconv.FormatFloat(*input.MaxPrice, 'f', -1, 64)}
+			clauses = append(clauses, "price <= :max_price")
+		}
+	}
+
+	if len(input.Labels) > 0 {
+		labelConds := make([]string, len(input.Labels))
+		for i, label := range input.Labels {
+			key := fmt.Sprintf(":label%d", i)
+			values[key] = &types.AttributeValueMemberS{Value: label}
+			labelConds[i] = fmt.Sprintf("contains(labels, %s)", key)
+		}
+		clauses = append(clauses, "("+strings.Join(labelConds, " OR ")+")")
+	}
+
+	if !input.IncludeArchived {
+		values[":not_archived"] = &types.AttributeValueMemberBOOL{Value: false}
+		clauses = append(clauses, "(attribute_not_exists(archived) OR archived = :not_archived)")
+	}
+
+	if input.OwnerID != "" {
+		values[":owner_id"] = &types.AttributeValueMemberS{Value: input.OwnerID}
+		clauses = append(clauses, "owner_id = :owner_id")
+	}
+
+	if len(clauses) == 0 {
+		return "", nil, nil
+	}
+	return strings.Join(clauses, " AND "), names, values
+}
+
+// buildItemQueryPlan combines buildItemIndexPlan and buildItemFilter into
+// the single plan ListItems and countItems both execute against.
+func buildItemQueryPlan(input ListItemsInput) itemQueryPlan {
+	indexName, keyCondition, keyNames, keyValues := buildItemIndexPlan(input)
+	filterExpression, filterNames, filterValues := buildItemFilter(input, indexName)
+
+	return itemQueryPlan{
+		indexName:        indexName,
+		keyCondition:     keyCondition,
+		keyNames:         keyNames,
+		keyValues:        keyValues,
+		filterExpression: filterExpression,
+		filterNames:      filterNames,
+		filterValues:     filterValues,
+	}
+}
+
+// queryItems runs a Query against plan's GSI instead of a full-table Scan,
+// with plan's FilterExpression (if any) layered on top. selectMode is
+// passed straight through to the QueryInput's Select field; the zero value
+// leaves DynamoDB's default (return the items) in place, while
+// types.SelectCount is used by countItems to avoid paying to transfer item
+// data it only needs the count of.
+func (db *DynamoDBClient) queryItems(ctx context.Context, plan itemQueryPlan, startKey map[string]types.AttributeValue, limit int64, selectMode types.Select) (*dynamodb.QueryOutput, error) {
+	exprValues := make(map[string]types.AttributeValue, len(plan.keyValues)+len(plan.filterValues))
+	for k, v := range plan.keyValues {
+		exprValues[k] = v
+	}
+	for k, v := range plan.filterValues {
+		exprValues[k] = v
+	}
+
+	exprNames := make(map[string]string, len(plan.keyNames)+len(plan.filterNames))
+	for k, v := range plan.keyNames {
+		exprNames[k] = v
+	}
+	for k, v := range plan.filterNames {
+		exprNames[k] = v
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		TableName:                 aws.String(db.tableName),
+		IndexName:                 aws.String(plan.indexName),
+		KeyConditionExpression:    aws.String(plan.keyCondition),
+		ExpressionAttributeValues: exprValues,
+		ExclusiveStartKey:         startKey,
+		ReturnConsumedCapacity:    types.ReturnConsumedCapacityTotal,
+		Select:                    selectMode,
+	}
+	if limit > 0 {
+		queryInput.Limit = aws.Int32(int32(limit))
+	}
+	if plan.filterExpression != "" {
+		queryInput.FilterExpression = aws.String(plan.filterExpression)
+	}
+	if len(exprNames) > 0 {
+		queryInput.ExpressionAttributeNames = exprNames
+	}
+
+	return db.client.Query(ctx, queryInput)
+}
+
+// countItems re-runs plan as a Select: COUNT pass, looping until DynamoDB
+// stops returning a LastEvaluatedKey, so the total reflects every matching
+// item rather than just the page ListItems returns to the caller.
+func (db *DynamoDBClient) countItems(ctx context.Context, plan itemQueryPlan) (int, error) {
+	var (
+		total    int
+		startKey map[string]types.AttributeValue
+	)
+
+	for {
+		var (
+			count   int32
+			lastKey map[string]types.AttributeValue
+		)
+
+		if plan.indexName != "" {
+			result, err := db.queryItems(ctx, plan, startKey, 0, types.SelectCount)
+			if err != nil {
+				return total, err
+			}
+			count, lastKey = result.Count, result.LastEvaluatedKey
+		} else {
+			scanInput := &dynamodb.ScanInput{
+				TableName:         aws.String(db.tableName),
+				Select:            types.SelectCount,
+				ExclusiveStartKey: startKey,
+			}
+			if plan.filterExpression != "" {
+				scanInput.FilterExpression = aws.String(plan.filterExpression)
+				scanInput.ExpressionAttributeValues = plan.filterValues
+				if len(plan.filterNames) > 0 {
+					scanInput.ExpressionAttributeNames = plan.filterNames
+				}
+			}
+			result, err := db.client.Scan(ctx, scanInput)
+			if err != nil {
+				return total, err
+			}
+			count, lastKey = result.Count, result.LastEvaluatedKey
+		}
+
+		total += int(count)
+		startKey = lastKey
+		if len(startKey) == 0 {
+			return total, nil
+		}
+	}
+}
+
+// sortItems re-orders items in place by column ("name", "price",
+// "created_at", or "updated_at"; default "created_at") and order ("asc" or
+// "desc"; default "asc"). It only orders the page already fetched - see
+// ListItemsInput.SortColumn.
+func sortItems(items []models.Item, column, order string) {
+	desc := order == "desc"
+
+	less := func(i, j int) bool {
+		switch column {
+		case "name":
+			return items[i].Name < items[j].Name
+		case "price":
+			return items[i].Price < items[j].Price
+		case "updated_at":
+			return items[i].UpdatedAt < items[j].UpdatedAt
+		default:
+			return items[i].CreatedAt < items[j].CreatedAt
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if desc {
+			return less(j, i)
+		}
+		return less(i, j)
+	})
 }
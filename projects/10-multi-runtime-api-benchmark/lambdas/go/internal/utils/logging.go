@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/aws/aws-lambda-go/lambdacontext"
+)
+
+// Logger emits structured JSON log records (request ID, item ID, latency,
+// DynamoDB consumed capacity, cold-start flag) instead of the plain-text
+// log.Printf lines DynamoDBClient and MetricsCollector used to write.
+var Logger = slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+// logDynamoDBCall records a single DynamoDB operation: the item it touched
+// (if any), how long it took, the consumed capacity DynamoDB reported, and
+// whether this is a cold Lambda invocation.
+func logDynamoDBCall(ctx context.Context, op, itemID string, start time.Time, consumedCapacity float64, err error) {
+	attrs := []any{
+		slog.String("operation", op),
+		slog.Duration("latency", time.Since(start)),
+		slog.Bool("cold_start", IsColdStart()),
+	}
+	if itemID != "" {
+		attrs = append(attrs, slog.String("item_id", itemID))
+	}
+	if consumedCapacity > 0 {
+		attrs = append(attrs, slog.Float64("consumed_capacity", consumedCapacity))
+	}
+	if requestID, ok := ctx.Value(requestIDKey{}).(string); ok {
+		attrs = append(attrs, slog.String("request_id", requestID))
+	}
+
+	RecordDDBCall(op, time.Since(start), err)
+
+	if err != nil {
+		IncrementDDBErrorCount()
+		Logger.Error("dynamodb call failed", append(attrs, slog.String("error", err.Error()))...)
+		return
+	}
+	Logger.Info("dynamodb call", attrs...)
+}
+
+// requestIDKey is the context key logDynamoDBCall and the logging middleware
+// use to correlate a DynamoDB call with the HTTP request that triggered it.
+type requestIDKey struct{}
+
+// WithRequestID returns a context carrying the given request ID for
+// downstream DynamoDB call logging.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// LogHTTPRequest records one completed HTTP request under the same
+// request_id DynamoDB calls it triggered were logged with, plus the route,
+// method, status, latency, cold-start flag, and (when running in Lambda)
+// the invocation's aws_request_id from lambdacontext - the CloudWatch
+// Insights-queryable replacement for gin.Default()'s stdout access log.
+func LogHTTPRequest(ctx context.Context, method, route string, status int, latency time.Duration) {
+	attrs := []any{
+		slog.String("method", method),
+		slog.String("route", route),
+		slog.Int("status", status),
+		slog.Float64("latency_ms", float64(latency.Microseconds())/1000),
+		slog.Bool("cold_start", IsColdStart()),
+	}
+	if requestID, ok := ctx.Value(requestIDKey{}).(string); ok {
+		attrs = append(attrs, slog.String("request_id", requestID))
+	}
+	if lc, ok := lambdacontext.FromContext(ctx); ok {
+		attrs = append(attrs, slog.String("aws_request_id", lc.AwsRequestID))
+	}
+	Logger.Info("http request", attrs...)
+}
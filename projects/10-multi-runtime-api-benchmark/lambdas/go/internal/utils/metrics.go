@@ -1,25 +1,227 @@
 package utils
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
 	"log"
 	"os"
 	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
 )
 
 var (
-	coldStart  = true
-	startTime  = time.Now()
+	coldStart = true
+	startTime = time.Now()
+
+	daxPathCount    int64
+	dynamoPathCount int64
+
+	requestCount   int64
+	ddbErrorCount  int64
+	coldStartCount int64
+)
+
+// promRegistry holds the labeled metrics PrometheusText exposes alongside
+// the plain counters above. It's a dedicated registry rather than
+// prometheus.DefaultRegisterer so repeated NewMetricsCollector calls in
+// tests never hit a duplicate-registration panic.
+var promRegistry = prometheus.NewRegistry()
+
+var (
+	httpRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "go_lambda_http_requests_total",
+		Help: "Total HTTP requests handled, labeled by route, method, and status.",
+	}, []string{"route", "method", "status"})
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "go_lambda_http_request_duration_seconds",
+		Help:    "HTTP handler latency in seconds, labeled by route and method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"route", "method"})
+
+	ddbCallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "go_lambda_ddb_call_duration_seconds",
+		Help:    "DynamoDB call latency in seconds, labeled by operation.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"operation"})
+
+	ddbErrorsByOp = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "go_lambda_ddb_operation_errors_total",
+		Help: "Total failed DynamoDB calls, labeled by operation.",
+	}, []string{"operation"})
+
+	coldStartGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "go_lambda_cold_start",
+		Help: "1 while this container is still serving its first invocation, 0 once warm.",
+	})
 )
 
+func init() {
+	promRegistry.MustRegister(httpRequestsTotal, httpRequestDuration, ddbCallDuration, ddbErrorsByOp, coldStartGauge)
+	coldStartGauge.Set(1)
+}
+
+// RecordHTTPRequest records one completed HTTP request against the
+// route/method/status-labeled counter and the route/method latency
+// histogram, for the /metrics/prom endpoint. Called by tracingMiddleware
+// once a request finishes.
+func RecordHTTPRequest(route, method string, status int, duration time.Duration) {
+	httpRequestsTotal.WithLabelValues(route, method, strconv.Itoa(status)).Inc()
+	httpRequestDuration.WithLabelValues(route, method).Observe(duration.Seconds())
+	coldStartGauge.Set(0)
+}
+
+// RecordDDBCall records one completed DynamoDB call against the
+// operation-labeled latency histogram, and the operation-labeled error
+// counter if it failed. Called by logDynamoDBCall.
+func RecordDDBCall(operation string, duration time.Duration, err error) {
+	ddbCallDuration.WithLabelValues(operation).Observe(duration.Seconds())
+	if err != nil {
+		ddbErrorsByOp.WithLabelValues(operation).Inc()
+	}
+}
+
+// IsColdStart reports whether this Lambda container is still cold, i.e. no
+// MetricsCollector has been created in it yet. It stays true only until the
+// first MetricsCollector is created.
+func IsColdStart() bool {
+	return coldStart
+}
+
+// IncrementRequestCount records one more handled HTTP request, for the
+// Prometheus request-count metric.
+func IncrementRequestCount() {
+	atomic.AddInt64(&requestCount, 1)
+}
+
+// IncrementDDBErrorCount records one more failed DynamoDB call, for the
+// Prometheus DDB-error-count metric.
+func IncrementDDBErrorCount() {
+	atomic.AddInt64(&ddbErrorCount, 1)
+}
+
+// PrometheusText renders the counters collected so far in Prometheus text
+// exposition format for the /metrics/prom endpoint: the plain invocation
+// totals below, followed by the labeled request/latency/DynamoDB/cold-start
+// metrics registered on promRegistry (request counters by route/method/
+// status, per-handler latency histograms, DynamoDB call duration and
+// per-operation error counters, and the cold-start gauge).
+func PrometheusText() string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf,
+		"# HELP go_lambda_requests_total Total HTTP requests handled.\n"+
+			"# TYPE go_lambda_requests_total counter\n"+
+			"go_lambda_requests_total %d\n"+
+			"# HELP go_lambda_ddb_errors_total Total failed DynamoDB calls.\n"+
+			"# TYPE go_lambda_ddb_errors_total counter\n"+
+			"go_lambda_ddb_errors_total %d\n"+
+			"# HELP go_lambda_cold_starts_total Total cold-start invocations.\n"+
+			"# TYPE go_lambda_cold_starts_total counter\n"+
+			"go_lambda_cold_starts_total %d\n",
+		atomic.LoadInt64(&requestCount),
+		atomic.LoadInt64(&ddbErrorCount),
+		atomic.LoadInt64(&coldStartCount),
+	)
+
+	families, err := promRegistry.Gather()
+	if err != nil {
+		log.Printf("Error gathering Prometheus metrics: %v", err)
+		return buf.String()
+	}
+
+	encoder := expfmt.NewEncoder(&buf, expfmt.NewFormat(expfmt.TypeTextPlain))
+	for _, mf := range families {
+		if err := encoder.Encode(mf); err != nil {
+			log.Printf("Error encoding Prometheus metric family %s: %v", mf.GetName(), err)
+		}
+	}
+
+	return buf.String()
+}
+
+// RecordBackendCall increments the cache-path or direct-path counter
+// depending on which DynamoDBAPI backend served a request, so benchmark
+// runs can compare DAX vs raw DynamoDB traffic split.
+func RecordBackendCall(backend string) {
+	if backend == "dax" {
+		atomic.AddInt64(&daxPathCount, 1)
+	} else {
+		atomic.AddInt64(&dynamoPathCount, 1)
+	}
+}
+
+// BackendCallCounts returns the cumulative number of DynamoDB calls served
+// via DAX vs. directly against DynamoDB.
+func BackendCallCounts() (dax int64, dynamo int64) {
+	return atomic.LoadInt64(&daxPathCount), atomic.LoadInt64(&dynamoPathCount)
+}
+
 // MetricsCollector collects runtime metrics
 type MetricsCollector struct {
 	RuntimeName string
 	StartTime   time.Time
 	ColdStart   bool
+
+	sink     MetricsSink
+	sinkName string
+
+	mu       sync.Mutex
+	counters map[string]CustomMetric
+	timers   map[string]CustomMetric
+}
+
+// CustomMetric is a dynamically-registered Counter or Timer value, carried
+// on Metrics.Custom so every MetricsSink sees it without reaching back into
+// the collector that recorded it.
+type CustomMetric struct {
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit"`
+}
+
+// defaultEMFNamespace is the CloudWatch namespace EMFSink publishes under
+// when METRICS_NAMESPACE isn't set.
+const defaultEMFNamespace = "BenchmarkGoLambda"
+
+// Counter accumulates value under name, to be included in Metrics.Custom the
+// next time Emit runs. Calling it again with the same name adds to the
+// running total for this collector's lifetime.
+func (mc *MetricsCollector) Counter(name string, value float64, unit string) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	if mc.counters == nil {
+		mc.counters = make(map[string]CustomMetric)
+	}
+	m := mc.counters[name]
+	m.Value += value
+	m.Unit = unit
+	mc.counters[name] = m
+}
+
+// Timer records duration d under name as a Milliseconds custom metric.
+// Unlike Counter, each call replaces the previous value rather than
+// accumulating.
+func (mc *MetricsCollector) Timer(name string, d time.Duration) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	if mc.timers == nil {
+		mc.timers = make(map[string]CustomMetric)
+	}
+	mc.timers[name] = CustomMetric{Value: float64(d.Milliseconds()), Unit: "Milliseconds"}
 }
 
-// NewMetricsCollector creates a new metrics collector
+// NewMetricsCollector creates a new metrics collector, wiring up the
+// MetricsSink selected by METRICS_SINK (see selectSink).
 func NewMetricsCollector() *MetricsCollector {
 	runtimeName := os.Getenv("RUNTIME_NAME")
 	if runtimeName == "" {
@@ -28,23 +230,33 @@ func NewMetricsCollector() *MetricsCollector {
 
 	isColdStart := coldStart
 	coldStart = false // Subsequent invocations are warm starts
+	if isColdStart {
+		atomic.AddInt64(&coldStartCount, 1)
+	} else {
+		coldStartGauge.Set(0)
+	}
+
+	sink, sinkName := selectSink(context.Background())
 
 	return &MetricsCollector{
 		RuntimeName: runtimeName,
 		StartTime:   startTime,
 		ColdStart:   isColdStart,
+		sink:        sink,
+		sinkName:    sinkName,
 	}
 }
 
 // Metrics represents runtime metrics
 type Metrics struct {
-	Runtime        string         `json:"runtime"`
-	ColdStart      bool           `json:"cold_start"`
-	UptimeSeconds  float64        `json:"uptime_seconds"`
-	Memory         MemoryMetrics  `json:"memory"`
-	GoVersion      string         `json:"go_version"`
-	Environment    string         `json:"environment"`
-	Lambda         *LambdaContext `json:"lambda,omitempty"`
+	Runtime       string                  `json:"runtime"`
+	ColdStart     bool                    `json:"cold_start"`
+	UptimeSeconds float64                 `json:"uptime_seconds"`
+	Memory        MemoryMetrics           `json:"memory"`
+	GoVersion     string                  `json:"go_version"`
+	Environment   string                  `json:"environment"`
+	Lambda        *LambdaContext          `json:"lambda,omitempty"`
+	Custom        map[string]CustomMetric `json:"custom,omitempty"`
 }
 
 // MemoryMetrics represents memory usage metrics
@@ -100,6 +312,136 @@ func (mc *MetricsCollector) GetMetrics() Metrics {
 	return metrics
 }
 
+// Emit collects the current metrics, plus every counter/timer recorded via
+// Counter/Timer on this collector so far (they are not reset between calls),
+// and flushes them through the
+// collector's MetricsSink. A sink error (most likely OTLPSink losing its
+// endpoint) is logged and degrades to JSONStdoutSink, so a telemetry outage
+// never fails the Lambda invocation that called Emit.
+func (mc *MetricsCollector) Emit(ctx context.Context) {
+	metrics := mc.GetMetrics()
+
+	mc.mu.Lock()
+	if len(mc.counters) > 0 || len(mc.timers) > 0 {
+		metrics.Custom = make(map[string]CustomMetric, len(mc.counters)+len(mc.timers))
+		for name, m := range mc.counters {
+			metrics.Custom[name] = m
+		}
+		for name, m := range mc.timers {
+			metrics.Custom[name] = m
+		}
+	}
+	mc.mu.Unlock()
+
+	if err := mc.sink.Emit(ctx, metrics); err != nil {
+		log.Printf("Error emitting metrics via %s sink, falling back to JSON stdout: %v", mc.sinkName, err)
+		if fbErr := (JSONStdoutSink{}).Emit(ctx, metrics); fbErr != nil {
+			log.Printf("Error emitting fallback JSON metrics: %v", fbErr)
+		}
+	}
+}
+
+// MetricsSink is the destination a MetricsCollector flushes a Metrics
+// snapshot to. Implementations must not fail the caller's request on their
+// own account; Emit already treats a returned error as "fell back to JSON".
+type MetricsSink interface {
+	Emit(ctx context.Context, metrics Metrics) error
+}
+
+// selectSink picks the MetricsSink NewMetricsCollector wires up, based on
+// METRICS_SINK ("json", "emf", or "otlp"; default "json"). An "otlp"
+// selection that fails to initialize (e.g. OTEL_EXPORTER_OTLP_ENDPOINT
+// unset) falls back to JSON at startup. An unreachable endpoint is not
+// detected here; it surfaces per call, where Emit falls back.
+func selectSink(ctx context.Context) (sink MetricsSink, name string) {
+	switch strings.ToLower(os.Getenv("METRICS_SINK")) {
+	case "emf":
+		return EMFSink{}, "emf"
+	case "otlp":
+		otlpSink, err := NewOTLPSink(ctx)
+		if err != nil {
+			log.Printf("Error creating OTLP metrics sink, falling back to JSON stdout: %v", err)
+			return JSONStdoutSink{}, "json"
+		}
+		return otlpSink, "otlp"
+	default:
+		return JSONStdoutSink{}, "json"
+	}
+}
+
+// JSONStdoutSink writes the Metrics snapshot as a single plain JSON line on
+// stdout - the sink selected when METRICS_SINK is unset.
+type JSONStdoutSink struct{}
+
+// Emit marshals metrics and prints it; the only failure mode is a
+// non-serializable Metrics value, which can't happen with the fields
+// defined on this type.
+func (JSONStdoutSink) Emit(ctx context.Context, metrics Metrics) error {
+	data, err := json.Marshal(metrics)
+	if err != nil {
+		return fmt.Errorf("marshaling metrics: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+// EMFSink writes metrics as a single CloudWatch Embedded Metric Format JSON
+// document on stdout. The Lambda log agent parses EMF automatically, so
+// this needs no CloudWatch PutMetricData call.
+type EMFSink struct{}
+
+// Emit builds the EMF document for metrics, including any Custom
+// counters/timers, under the METRICS_NAMESPACE namespace.
+func (EMFSink) Emit(ctx context.Context, metrics Metrics) error {
+	coldStartValue := 0.0
+	if metrics.ColdStart {
+		coldStartValue = 1.0
+	}
+
+	functionName := ""
+	if metrics.Lambda != nil {
+		functionName = metrics.Lambda.FunctionName
+	}
+
+	metricDefs := []map[string]string{
+		{"Name": "MemoryAllocMB", "Unit": "Megabytes"},
+		{"Name": "UptimeSeconds", "Unit": "Seconds"},
+		{"Name": "ColdStart", "Unit": "Count"},
+	}
+
+	doc := map[string]interface{}{
+		"Runtime":       metrics.Runtime,
+		"Environment":   metrics.Environment,
+		"FunctionName":  functionName,
+		"MemoryAllocMB": metrics.Memory.AllocMB,
+		"UptimeSeconds": metrics.UptimeSeconds,
+		"ColdStart":     coldStartValue,
+	}
+
+	for name, m := range metrics.Custom {
+		metricDefs = append(metricDefs, map[string]string{"Name": name, "Unit": m.Unit})
+		doc[name] = m.Value
+	}
+
+	doc["_aws"] = map[string]interface{}{
+		"Timestamp": time.Now().UnixMilli(),
+		"CloudWatchMetrics": []map[string]interface{}{
+			{
+				"Namespace":  getEnv("METRICS_NAMESPACE", defaultEMFNamespace),
+				"Dimensions": [][]string{{"Runtime", "Environment", "FunctionName"}},
+				"Metrics":    metricDefs,
+			},
+		},
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("marshaling EMF metrics: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
 func getEnv(key, defaultValue string) string {
 	value := os.Getenv(key)
 	if value == "" {
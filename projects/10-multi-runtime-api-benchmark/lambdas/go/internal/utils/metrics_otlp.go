@@ -0,0 +1,149 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// OTLPSink exports metrics to an OTLP/HTTP endpoint via
+// go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp, honoring
+// OTEL_EXPORTER_OTLP_ENDPOINT (a URL, to which the exporter appends
+// /v1/metrics) and OTEL_EXPORTER_OTLP_HEADERS. Emit calls
+// provider.ForceFlush so an unreachable collector surfaces as an error on
+// the call that hit it, instead of being swallowed by the exporter's
+// background batching - MetricsCollector.Emit then falls back to JSON stdout.
+type OTLPSink struct {
+	provider *sdkmetric.MeterProvider
+
+	allocGauge      metric.Float64Gauge
+	sysGauge        metric.Float64Gauge
+	totalAllocGauge metric.Float64Gauge
+	uptimeGauge     metric.Float64Gauge
+	numGCCounter    metric.Int64Counter
+	coldStartCount  metric.Int64Counter
+
+	mu          sync.Mutex
+	lastNumGC   uint32
+	coldStartOn sync.Once
+}
+
+// NewOTLPSink builds an OTLPSink exporting to OTEL_EXPORTER_OTLP_ENDPOINT.
+// It errors out if that endpoint isn't set, so selectSink can fall back to
+// JSON stdout instead of wiring up a sink with nowhere to send metrics.
+func NewOTLPSink(ctx context.Context) (*OTLPSink, error) {
+	endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	if endpoint == "" {
+		return nil, fmt.Errorf("OTEL_EXPORTER_OTLP_ENDPOINT is not set")
+	}
+
+	// The exporter reads OTEL_EXPORTER_OTLP_ENDPOINT from the environment
+	// itself, as a URL; WithEndpoint would take only host:port.
+	var opts []otlpmetrichttp.Option
+	if headers := parseOTLPHeaders(os.Getenv("OTEL_EXPORTER_OTLP_HEADERS")); len(headers) > 0 {
+		opts = append(opts, otlpmetrichttp.WithHeaders(headers))
+	}
+
+	exporter, err := otlpmetrichttp.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating OTLP metric exporter for %s: %w", endpoint, err)
+	}
+
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter)))
+	meter := provider.Meter("benchmark-go-lambda")
+
+	sink := &OTLPSink{provider: provider}
+
+	if sink.allocGauge, err = meter.Float64Gauge("lambda.memory.alloc_mb", metric.WithUnit("MiBy")); err != nil {
+		return nil, fmt.Errorf("creating alloc_mb gauge: %w", err)
+	}
+	if sink.sysGauge, err = meter.Float64Gauge("lambda.memory.sys_mb", metric.WithUnit("MiBy")); err != nil {
+		return nil, fmt.Errorf("creating sys_mb gauge: %w", err)
+	}
+	if sink.totalAllocGauge, err = meter.Float64Gauge("lambda.memory.total_alloc_mb", metric.WithUnit("MiBy")); err != nil {
+		return nil, fmt.Errorf("creating total_alloc_mb gauge: %w", err)
+	}
+	if sink.uptimeGauge, err = meter.Float64Gauge("lambda.uptime_seconds", metric.WithUnit("s")); err != nil {
+		return nil, fmt.Errorf("creating uptime_seconds gauge: %w", err)
+	}
+	if sink.numGCCounter, err = meter.Int64Counter("lambda.memory.num_gc", metric.WithUnit("{collection}")); err != nil {
+		return nil, fmt.Errorf("creating num_gc counter: %w", err)
+	}
+	if sink.coldStartCount, err = meter.Int64Counter("lambda.cold_starts", metric.WithUnit("{invocation}")); err != nil {
+		return nil, fmt.Errorf("creating cold_starts counter: %w", err)
+	}
+
+	return sink, nil
+}
+
+// Emit records metrics against this sink's instruments and force-flushes
+// the exporter so a network failure is reported to the caller synchronously
+// rather than lost in the next periodic export cycle.
+func (s *OTLPSink) Emit(ctx context.Context, metrics Metrics) error {
+	attrs := metric.WithAttributes(
+		attribute.String("runtime", metrics.Runtime),
+		attribute.String("environment", metrics.Environment),
+		attribute.String("function_name", lambdaFunctionName(metrics)),
+	)
+
+	s.allocGauge.Record(ctx, metrics.Memory.AllocMB, attrs)
+	s.sysGauge.Record(ctx, metrics.Memory.SysMB, attrs)
+	s.totalAllocGauge.Record(ctx, metrics.Memory.TotalAllocMB, attrs)
+	s.uptimeGauge.Record(ctx, metrics.UptimeSeconds, attrs)
+
+	s.mu.Lock()
+	delta := int64(metrics.Memory.NumGC) - int64(s.lastNumGC)
+	if delta > 0 {
+		s.lastNumGC = metrics.Memory.NumGC
+	}
+	s.mu.Unlock()
+	if delta > 0 {
+		s.numGCCounter.Add(ctx, delta, attrs)
+	}
+
+	if metrics.ColdStart {
+		s.coldStartOn.Do(func() {
+			s.coldStartCount.Add(ctx, 1, attrs)
+		})
+	}
+
+	if err := s.provider.ForceFlush(ctx); err != nil {
+		return fmt.Errorf("flushing metrics to OTLP endpoint: %w", err)
+	}
+	return nil
+}
+
+// lambdaFunctionName returns the Lambda function name carried on metrics,
+// or "" outside of a Lambda environment.
+func lambdaFunctionName(metrics Metrics) string {
+	if metrics.Lambda == nil {
+		return ""
+	}
+	return metrics.Lambda.FunctionName
+}
+
+// parseOTLPHeaders parses OTEL_EXPORTER_OTLP_HEADERS, a comma-separated
+// list of key=value pairs per the OpenTelemetry exporter spec, into the map
+// otlpmetrichttp.WithHeaders expects.
+func parseOTLPHeaders(raw string) map[string]string {
+	if raw == "" {
+		return nil
+	}
+
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(raw, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || key == "" {
+			continue
+		}
+		headers[key] = strings.TrimSpace(value)
+	}
+	return headers
+}
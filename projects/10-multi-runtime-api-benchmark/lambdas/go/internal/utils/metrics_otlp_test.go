@@ -0,0 +1,146 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestNewOTLPSinkRequiresEndpoint(t *testing.T) {
+	os.Unsetenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+
+	if _, err := NewOTLPSink(context.Background()); err == nil {
+		t.Fatal("Expected an error when OTEL_EXPORTER_OTLP_ENDPOINT is unset")
+	}
+}
+
+func TestNewOTLPSinkExportsToConfiguredEndpoint(t *testing.T) {
+	var gotContentType string
+	received := make(chan struct{}, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotContentType = r.Header.Get("Content-Type")
+		w.WriteHeader(http.StatusOK)
+		select {
+		case received <- struct{}{}:
+		default:
+		}
+	}))
+	defer server.Close()
+
+	os.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", server.URL)
+	os.Setenv("OTEL_EXPORTER_OTLP_HEADERS", "x-api-key=secret, empty=")
+	defer os.Unsetenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	defer os.Unsetenv("OTEL_EXPORTER_OTLP_HEADERS")
+
+	sink, err := NewOTLPSink(context.Background())
+	if err != nil {
+		t.Fatalf("Expected OTLPSink to build against a live endpoint, got: %v", err)
+	}
+
+	metrics := Metrics{
+		Runtime:     "go-test",
+		Environment: "test",
+		ColdStart:   true,
+		Memory:      MemoryMetrics{AllocMB: 12, SysMB: 34, TotalAllocMB: 56, NumGC: 2},
+		Lambda:      &LambdaContext{FunctionName: "benchmark-fn"},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := sink.Emit(ctx, metrics); err != nil {
+		t.Fatalf("Expected Emit against a reachable endpoint to succeed, got: %v", err)
+	}
+
+	select {
+	case <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the in-memory OTLP receiver to observe an export request")
+	}
+
+	if gotContentType != "application/x-protobuf" {
+		t.Errorf("Expected OTLP/HTTP protobuf content type, got %q", gotContentType)
+	}
+}
+
+func TestOTLPSinkEmitErrorsWhenEndpointUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	endpoint := server.URL
+	server.Close() // closed immediately: endpoint is now unreachable
+
+	os.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", endpoint)
+	defer os.Unsetenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+
+	sink, err := NewOTLPSink(context.Background())
+	if err != nil {
+		t.Fatalf("Expected NewOTLPSink to succeed even if the endpoint later becomes unreachable, got: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := sink.Emit(ctx, Metrics{Runtime: "go-test"}); err == nil {
+		t.Fatal("Expected Emit to return an error for an unreachable OTLP endpoint")
+	}
+
+	// Collector-level fallback to JSON stdout on this error is covered by
+	// TestEmitFallsBackToJSONWhenSinkErrors.
+}
+
+func TestLambdaFunctionNameWithoutLambdaContext(t *testing.T) {
+	if got := lambdaFunctionName(Metrics{}); got != "" {
+		t.Errorf("Expected empty function name outside Lambda, got %q", got)
+	}
+}
+
+func TestLambdaFunctionNameWithLambdaContext(t *testing.T) {
+	metrics := Metrics{Lambda: &LambdaContext{FunctionName: "benchmark-fn"}}
+	if got := lambdaFunctionName(metrics); got != "benchmark-fn" {
+		t.Errorf("Expected function name 'benchmark-fn', got %q", got)
+	}
+}
+
+func TestParseOTLPHeaders(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want map[string]string
+	}{
+		{name: "empty string returns nil", raw: "", want: nil},
+		{
+			name: "single header",
+			raw:  "x-api-key=secret",
+			want: map[string]string{"x-api-key": "secret"},
+		},
+		{
+			name: "multiple headers with surrounding whitespace",
+			raw:  "x-api-key=secret, x-team = platform",
+			want: map[string]string{"x-api-key": "secret", "x-team": "platform"},
+		},
+		{
+			name: "entries without '=' are skipped",
+			raw:  "x-api-key=secret, malformed",
+			want: map[string]string{"x-api-key": "secret"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseOTLPHeaders(tt.raw)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %v, got %v", tt.want, got)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("Expected header %s=%s, got %s=%s", k, v, k, got[k])
+				}
+			}
+		})
+	}
+}
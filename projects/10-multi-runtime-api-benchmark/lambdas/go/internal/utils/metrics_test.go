@@ -1,9 +1,13 @@
 package utils
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"os"
 	"runtime"
+	"strings"
 	"testing"
 	"time"
 )
@@ -261,6 +265,203 @@ func TestMetricsJSONSerialization(t *testing.T) {
 	}
 }
 
+func TestPrometheusTextContainsExpectedMetrics(t *testing.T) {
+	IncrementRequestCount()
+	IncrementDDBErrorCount()
+
+	text := PrometheusText()
+
+	for _, want := range []string{"go_lambda_requests_total", "go_lambda_ddb_errors_total", "go_lambda_cold_starts_total"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected Prometheus output to contain %q, got:\n%s", want, text)
+		}
+	}
+}
+
+func TestPrometheusTextContainsLabeledMetrics(t *testing.T) {
+	RecordHTTPRequest("/items/:id", "GET", 200, 5*time.Millisecond)
+	RecordDDBCall("GetItem", 2*time.Millisecond, nil)
+	RecordDDBCall("GetItem", 2*time.Millisecond, errors.New("boom"))
+
+	text := PrometheusText()
+
+	for _, want := range []string{
+		"go_lambda_http_requests_total",
+		"go_lambda_http_request_duration_seconds",
+		"go_lambda_ddb_call_duration_seconds",
+		"go_lambda_ddb_operation_errors_total",
+		"go_lambda_cold_start",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected Prometheus output to contain %q, got:\n%s", want, text)
+		}
+	}
+
+	if !strings.Contains(text, `operation="GetItem"`) {
+		t.Errorf("Expected DDB metrics labeled by operation, got:\n%s", text)
+	}
+}
+
+func TestCounterAccumulates(t *testing.T) {
+	collector := NewMetricsCollector()
+
+	collector.Counter("ddb_retries", 1, "Count")
+	collector.Counter("ddb_retries", 2, "Count")
+
+	if got := collector.counters["ddb_retries"].Value; got != 3 {
+		t.Errorf("Expected accumulated counter value 3, got %f", got)
+	}
+}
+
+func TestTimerReplacesPreviousValue(t *testing.T) {
+	collector := NewMetricsCollector()
+
+	collector.Timer("ddb_latency", 10*time.Millisecond)
+	collector.Timer("ddb_latency", 25*time.Millisecond)
+
+	if got := collector.timers["ddb_latency"].Value; got != 25 {
+		t.Errorf("Expected latest timer value 25, got %f", got)
+	}
+}
+
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	origStdout := os.Stdout
+	os.Stdout = w
+	fn()
+	w.Close()
+	os.Stdout = origStdout
+
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(r); err != nil {
+		t.Fatalf("Failed to read captured stdout: %v", err)
+	}
+	return buf.String()
+}
+
+func TestEMFSinkWritesCloudWatchDocument(t *testing.T) {
+	os.Setenv("RUNTIME_NAME", "go-test")
+	defer os.Unsetenv("RUNTIME_NAME")
+
+	collector := NewMetricsCollector()
+	collector.Counter("ddb_retries", 4, "Count")
+
+	out := captureStdout(t, func() {
+		collector.sink = EMFSink{}
+		collector.Emit(context.Background())
+	})
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("EMFSink output is not valid JSON: %v\n%s", err, out)
+	}
+
+	if doc["Runtime"] != "go-test" {
+		t.Errorf("Expected Runtime 'go-test', got %v", doc["Runtime"])
+	}
+	if doc["ddb_retries"] != float64(4) {
+		t.Errorf("Expected ddb_retries 4, got %v", doc["ddb_retries"])
+	}
+
+	aws, ok := doc["_aws"].(map[string]interface{})
+	if !ok {
+		t.Fatal("Expected an _aws object in the EMF document")
+	}
+	metricsBlocks, ok := aws["CloudWatchMetrics"].([]interface{})
+	if !ok || len(metricsBlocks) != 1 {
+		t.Fatalf("Expected one CloudWatchMetrics block, got %v", aws["CloudWatchMetrics"])
+	}
+}
+
+func TestJSONStdoutSinkWritesPlainJSON(t *testing.T) {
+	os.Setenv("RUNTIME_NAME", "go-test")
+	defer os.Unsetenv("RUNTIME_NAME")
+
+	collector := NewMetricsCollector()
+
+	out := captureStdout(t, func() {
+		collector.sink = JSONStdoutSink{}
+		collector.Emit(context.Background())
+	})
+
+	var metrics Metrics
+	if err := json.Unmarshal([]byte(out), &metrics); err != nil {
+		t.Fatalf("JSONStdoutSink output is not valid Metrics JSON: %v\n%s", err, out)
+	}
+	if metrics.Runtime != "go-test" {
+		t.Errorf("Expected runtime 'go-test', got %q", metrics.Runtime)
+	}
+}
+
+func TestSelectSinkDefaultsToJSON(t *testing.T) {
+	os.Unsetenv("METRICS_SINK")
+
+	sink, name := selectSink(context.Background())
+
+	if name != "json" {
+		t.Errorf("Expected default sink name 'json', got %q", name)
+	}
+	if _, ok := sink.(JSONStdoutSink); !ok {
+		t.Errorf("Expected a JSONStdoutSink, got %T", sink)
+	}
+}
+
+func TestSelectSinkEMF(t *testing.T) {
+	os.Setenv("METRICS_SINK", "emf")
+	defer os.Unsetenv("METRICS_SINK")
+
+	sink, name := selectSink(context.Background())
+
+	if name != "emf" {
+		t.Errorf("Expected sink name 'emf', got %q", name)
+	}
+	if _, ok := sink.(EMFSink); !ok {
+		t.Errorf("Expected an EMFSink, got %T", sink)
+	}
+}
+
+func TestSelectSinkOTLPFallsBackToJSONWithoutEndpoint(t *testing.T) {
+	os.Setenv("METRICS_SINK", "otlp")
+	os.Unsetenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	defer os.Unsetenv("METRICS_SINK")
+
+	sink, name := selectSink(context.Background())
+
+	if name != "json" {
+		t.Errorf("Expected fallback sink name 'json', got %q", name)
+	}
+	if _, ok := sink.(JSONStdoutSink); !ok {
+		t.Errorf("Expected a JSONStdoutSink fallback, got %T", sink)
+	}
+}
+
+func TestEmitFallsBackToJSONWhenSinkErrors(t *testing.T) {
+	collector := NewMetricsCollector()
+	collector.sink = failingSink{}
+
+	out := captureStdout(t, func() {
+		collector.Emit(context.Background())
+	})
+
+	var metrics Metrics
+	if err := json.Unmarshal([]byte(out), &metrics); err != nil {
+		t.Fatalf("Expected Emit to fall back to JSON stdout, got: %v\n%s", err, out)
+	}
+}
+
+// failingSink always errors, to exercise Emit's fallback-to-JSON path
+// without depending on a real, unreachable OTLP endpoint.
+type failingSink struct{}
+
+func (failingSink) Emit(ctx context.Context, metrics Metrics) error {
+	return errors.New("sink unreachable")
+}
+
 func TestGetEnv(t *testing.T) {
 	tests := []struct {
 		name         string
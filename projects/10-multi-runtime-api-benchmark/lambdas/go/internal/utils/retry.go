@@ -0,0 +1,132 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go"
+)
+
+// retryBackoffBase is the starting delay for withBackoff's decorrelated
+// jitter; retryBackoffCap bounds how large a single sleep can grow to.
+const (
+	retryBackoffBase = 50 * time.Millisecond
+	retryBackoffCap  = 5 * time.Second
+)
+
+// Metrics is the process-wide MetricsCollector, set by cmd/main's init(), so
+// withBackoff can register retry-attempt counts without an import cycle back
+// to cmd. It's nil until that assignment happens (e.g. in tests), so every
+// use below is nil-guarded.
+var Metrics *MetricsCollector
+
+var (
+	retryErrMu   sync.Mutex
+	lastRetryErr error
+)
+
+// LastRetryError returns the most recent error withBackoff retried past,
+// for diagnosing sustained throttling independently of the request that
+// eventually succeeded.
+func LastRetryError() error {
+	retryErrMu.Lock()
+	defer retryErrMu.Unlock()
+	return lastRetryErr
+}
+
+// withBackoff retries attempt until it reports done, returns a
+// non-retryable error, or maxAttempts is exhausted. Between attempts it
+// sleeps using decorrelated jitter: sleep = min(cap, random(base, prevSleep*3)),
+// which spreads out retries better than plain exponential backoff under
+// concurrent callers. A retryable error from attempt (throttling, or a
+// transaction cancellation that isn't a genuine conflict) is retried in
+// place of the UnprocessedItems/UnprocessedKeys loop DynamoDB batch calls
+// also drive by returning done=false, err=nil.
+func withBackoff(ctx context.Context, maxAttempts int, attempt func(attemptNum int) (done bool, err error)) error {
+	sleep := retryBackoffBase
+
+	for i := 0; i < maxAttempts; i++ {
+		done, err := attempt(i)
+		if err != nil && !isRetryableError(err) {
+			return err
+		}
+		if err == nil && done {
+			return nil
+		}
+
+		if err != nil {
+			retryErrMu.Lock()
+			lastRetryErr = err
+			retryErrMu.Unlock()
+		}
+		if Metrics != nil {
+			Metrics.Counter("dynamodb_retry_attempts_total", 1, "Count")
+		}
+
+		sleep = decorrelatedJitter(sleep)
+
+		select {
+		case <-time.After(sleep):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return fmt.Errorf("exceeded %d retry attempts", maxAttempts)
+}
+
+// decorrelatedJitter computes the next sleep duration from the previous one,
+// per the AWS Architecture Blog's "decorrelated jitter" backoff algorithm.
+func decorrelatedJitter(prevSleep time.Duration) time.Duration {
+	upper := int64(prevSleep) * 3
+	next := int64(retryBackoffBase) + rand.Int63n(upper-int64(retryBackoffBase)+1)
+	if next > int64(retryBackoffCap) {
+		next = int64(retryBackoffCap)
+	}
+	return time.Duration(next)
+}
+
+// isRetryableError reports whether err is a DynamoDB error class that's safe
+// to retry: provisioned-throughput/request-limit/throttling exceptions, or a
+// transaction cancellation where no reason was a genuine ConditionalCheckFailed.
+func isRetryableError(err error) bool {
+	var throughputErr *types.ProvisionedThroughputExceededException
+	if errors.As(err, &throughputErr) {
+		return true
+	}
+
+	var limitErr *types.RequestLimitExceeded
+	if errors.As(err, &limitErr) {
+		return true
+	}
+
+	var cancelErr *types.TransactionCanceledException
+	if errors.As(err, &cancelErr) {
+		return transactionCancellationRetryable(cancelErr)
+	}
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.ErrorCode() == "ThrottlingException"
+	}
+
+	return false
+}
+
+// transactionCancellationRetryable reports whether a canceled
+// TransactWriteItems call is worth retrying: false if any cancellation reason
+// is ConditionalCheckFailed (a real data conflict that would just fail
+// again), true for every other set of reasons.
+func transactionCancellationRetryable(cancelErr *types.TransactionCanceledException) bool {
+	for _, reason := range cancelErr.CancellationReasons {
+		if reason.Code != nil && *reason.Code == "ConditionalCheckFailed" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func TestWithBackoffRetriesUntilDone(t *testing.T) {
+	attempts := 0
+	err := withBackoff(context.Background(), 5, func(attemptNum int) (bool, error) {
+		attempts++
+		return attempts == 3, nil
+	})
+	if err != nil {
+		t.Fatalf("Expected success, got %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestWithBackoffReturnsNonRetryableErrorImmediately(t *testing.T) {
+	attempts := 0
+	wantErr := errors.New("boom")
+	err := withBackoff(context.Background(), 5, func(attemptNum int) (bool, error) {
+		attempts++
+		return false, wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Errorf("Expected wantErr, got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("Expected no retry for a non-retryable error, got %d attempts", attempts)
+	}
+}
+
+func TestWithBackoffExhaustsMaxAttempts(t *testing.T) {
+	err := withBackoff(context.Background(), 2, func(attemptNum int) (bool, error) {
+		return false, nil
+	})
+	if err == nil {
+		t.Fatal("Expected an error once maxAttempts is exhausted")
+	}
+}
+
+func TestIsRetryableError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"plain error", errors.New("boom"), false},
+		{"provisioned throughput", &types.ProvisionedThroughputExceededException{}, true},
+		{"request limit exceeded", &types.RequestLimitExceeded{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryableError(tt.err); got != tt.want {
+				t.Errorf("isRetryableError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecorrelatedJitterStaysWithinBounds(t *testing.T) {
+	sleep := retryBackoffBase
+	for i := 0; i < 20; i++ {
+		sleep = decorrelatedJitter(sleep)
+		if sleep < retryBackoffBase || sleep > retryBackoffCap {
+			t.Fatalf("decorrelatedJitter produced out-of-bounds sleep: %v", sleep)
+		}
+	}
+}
+
+func TestLastRetryErrorTracksMostRecent(t *testing.T) {
+	wantErr := &types.ProvisionedThroughputExceededException{}
+	calls := 0
+	_ = withBackoff(context.Background(), 2, func(attemptNum int) (bool, error) {
+		calls++
+		if calls == 1 {
+			return false, wantErr
+		}
+		return true, nil
+	})
+
+	if !errors.Is(LastRetryError(), error(wantErr)) {
+		t.Errorf("Expected LastRetryError to report the retried error, got %v", LastRetryError())
+	}
+}
+
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracer is shared by the Gin request middleware and every DynamoDBClient
+// call. When OTEL_EXPORTER_OTLP_ENDPOINT isn't set, InitTracer installs a
+// provider with no exporter, so spans are created but simply discarded.
+var Tracer trace.Tracer = otel.Tracer("benchmark-go-lambda")
+
+// InitTracer wires up the global OpenTelemetry tracer provider, exporting
+// via OTLP/HTTP when OTEL_EXPORTER_OTLP_ENDPOINT is set. The returned
+// shutdown func should be deferred by the caller to flush pending spans.
+func InitTracer(ctx context.Context) func(context.Context) error {
+	endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	if endpoint == "" {
+		tp := sdktrace.NewTracerProvider()
+		otel.SetTracerProvider(tp)
+		return tp.Shutdown
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpoint(endpoint))
+	if err != nil {
+		log.Printf("Error creating OTLP exporter for %s, tracing disabled: %v", endpoint, err)
+		tp := sdktrace.NewTracerProvider()
+		otel.SetTracerProvider(tp)
+		return tp.Shutdown
+	}
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter))
+	otel.SetTracerProvider(tp)
+	log.Printf("OpenTelemetry tracing exporting to %s", endpoint)
+	return tp.Shutdown
+}